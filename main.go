@@ -41,7 +41,7 @@ func main() {
 	}
 
 	for _, info := range ssidInfos {
-		log.Printf("-> %s", info.SSID)
+		log.Printf("-> %s", info.Name)
 	}
 
 	state, err = network.CheckDeviceState(conn, devObj)
@@ -0,0 +1,199 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Potsdam-Sensors/GoLinuxToolkit/unix"
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	NM_ACTIVE_CONNECTION_STATE_UNKNOWN      = 0 // the state of the connection is unknown
+	NM_ACTIVE_CONNECTION_STATE_ACTIVATING   = 1 // a network connection is being prepared
+	NM_ACTIVE_CONNECTION_STATE_ACTIVATED    = 2 // there is a connection to the network
+	NM_ACTIVE_CONNECTION_STATE_DEACTIVATING = 3 // the network connection is being torn down and cleaned up
+	NM_ACTIVE_CONNECTION_STATE_DEACTIVATED  = 4 // the network connection is disconnected and will be removed
+)
+
+var NM_ACTIVE_CONNECTION_STATE_MAP = map[uint32]string{
+	NM_ACTIVE_CONNECTION_STATE_UNKNOWN:      "Unknown",
+	NM_ACTIVE_CONNECTION_STATE_ACTIVATING:   "Activating",
+	NM_ACTIVE_CONNECTION_STATE_ACTIVATED:    "Activated",
+	NM_ACTIVE_CONNECTION_STATE_DEACTIVATING: "Deactivating",
+	NM_ACTIVE_CONNECTION_STATE_DEACTIVATED:  "Deactivated",
+}
+
+const (
+	NM_ACTIVE_CONNECTION_STATE_REASON_UNKNOWN               = 0  // the reason for the active connection state change is unknown
+	NM_ACTIVE_CONNECTION_STATE_REASON_NONE                  = 1  // no reason was given for the active connection state change
+	NM_ACTIVE_CONNECTION_STATE_REASON_USER_DISCONNECTED     = 2  // the active connection changed state because the user disconnected it
+	NM_ACTIVE_CONNECTION_STATE_REASON_DEVICE_DISCONNECTED   = 3  // the active connection changed state because the device it was using was disconnected
+	NM_ACTIVE_CONNECTION_STATE_REASON_SERVICE_STOPPED       = 4  // the service providing the VPN connection was stopped
+	NM_ACTIVE_CONNECTION_STATE_REASON_IP_CONFIG_INVALID     = 5  // the IP config of the active connection was invalid
+	NM_ACTIVE_CONNECTION_STATE_REASON_CONNECT_TIMEOUT       = 6  // the connection attempt to the VPN service timed out
+	NM_ACTIVE_CONNECTION_STATE_REASON_SERVICE_START_TIMEOUT = 7  // a timeout occurred while starting the service providing the VPN connection
+	NM_ACTIVE_CONNECTION_STATE_REASON_SERVICE_START_FAILED  = 8  // starting the service providing the VPN connection failed
+	NM_ACTIVE_CONNECTION_STATE_REASON_NO_SECRETS            = 9  // necessary secrets for the connection were not provided
+	NM_ACTIVE_CONNECTION_STATE_REASON_LOGIN_FAILED          = 10 // authentication to the server failed
+	NM_ACTIVE_CONNECTION_STATE_REASON_CONNECTION_REMOVED    = 11 // the connection was deleted from settings
+	NM_ACTIVE_CONNECTION_STATE_REASON_DEPENDENCY_FAILED     = 12 // master connection of this connection failed to activate
+	NM_ACTIVE_CONNECTION_STATE_REASON_DEVICE_REALIZE_FAILED = 13 // could not create the software device link
+	NM_ACTIVE_CONNECTION_STATE_REASON_DEVICE_REMOVED        = 14 // the device this connection depended on disappeared
+)
+
+var NM_ACTIVE_CONNECTION_STATE_REASON_MAP = map[uint32]string{
+	NM_ACTIVE_CONNECTION_STATE_REASON_UNKNOWN:               "Unknown",
+	NM_ACTIVE_CONNECTION_STATE_REASON_NONE:                  "None",
+	NM_ACTIVE_CONNECTION_STATE_REASON_USER_DISCONNECTED:     "User disconnected",
+	NM_ACTIVE_CONNECTION_STATE_REASON_DEVICE_DISCONNECTED:   "Device disconnected",
+	NM_ACTIVE_CONNECTION_STATE_REASON_SERVICE_STOPPED:       "Service stopped",
+	NM_ACTIVE_CONNECTION_STATE_REASON_IP_CONFIG_INVALID:     "IP config invalid",
+	NM_ACTIVE_CONNECTION_STATE_REASON_CONNECT_TIMEOUT:       "Connect timeout",
+	NM_ACTIVE_CONNECTION_STATE_REASON_SERVICE_START_TIMEOUT: "Service start timeout",
+	NM_ACTIVE_CONNECTION_STATE_REASON_SERVICE_START_FAILED:  "Service start failed",
+	NM_ACTIVE_CONNECTION_STATE_REASON_NO_SECRETS:            "No secrets",
+	NM_ACTIVE_CONNECTION_STATE_REASON_LOGIN_FAILED:          "Login failed",
+	NM_ACTIVE_CONNECTION_STATE_REASON_CONNECTION_REMOVED:    "Connection removed",
+	NM_ACTIVE_CONNECTION_STATE_REASON_DEPENDENCY_FAILED:     "Dependency failed",
+	NM_ACTIVE_CONNECTION_STATE_REASON_DEVICE_REALIZE_FAILED: "Device realize failed",
+	NM_ACTIVE_CONNECTION_STATE_REASON_DEVICE_REMOVED:        "Device removed",
+}
+
+/*
+State is one of the NM_ACTIVE_CONNECTION_STATE_* values. Default is set on the connection
+holding the IPv4 default route, which is usually the primary one.
+*/
+type ActiveConnectionInfo struct {
+	Path    dbus.ObjectPath
+	ID      string
+	Type    string
+	State   uint32
+	Default bool
+	Devices []dbus.ObjectPath
+}
+
+func getActiveConnectionInfo(conn *dbus.Conn, acPath dbus.ObjectPath) (ActiveConnectionInfo, error) {
+	info := ActiveConnectionInfo{Path: acPath}
+	props := []struct {
+		name string
+		dest interface{}
+	}{
+		{"Id", &info.ID},
+		{"Type", &info.Type},
+		{"State", &info.State},
+		{"Default", &info.Default},
+	}
+	acObj := conn.Object(NetworkManagerInterface, acPath)
+	for _, p := range props {
+		variant, err := acObj.GetProperty(NetworkManagerActiveConnectionInterface + "." + p.name)
+		if err != nil {
+			return info, fmt.Errorf("failed to read %s of %s: %v", p.name, acPath, err)
+		}
+		err = variant.Store(p.dest)
+		if err != nil {
+			return info, fmt.Errorf("error storing %s of %s: %v", p.name, acPath, err)
+		}
+	}
+
+	devices, err := GetDevicesFromConnection(conn, acPath)
+	if err != nil {
+		return info, err
+	}
+	info.Devices = devices
+	return info, nil
+}
+
+// GetActiveConnections returns every currently active connection, e.g. both ethernet and WiFi on a multi-homed device.
+func GetActiveConnections(conn *dbus.Conn) ([]ActiveConnectionInfo, error) {
+	acPaths, err := unix.GetProperty[[]dbus.ObjectPath](conn, NetworkManagerInterface, NetworkManagerInterface, NetworkManagerObjectPath, "ActiveConnections")
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]ActiveConnectionInfo, 0, len(acPaths))
+	for _, acPath := range acPaths {
+		info, err := getActiveConnectionInfo(conn, acPath)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+/*
+C <- (new state, reason), one of the NM_ACTIVE_CONNECTION_STATE_* and NM_ACTIVE_CONNECTION_STATE_REASON_* values
+*/
+type ActiveConnStateSubscription struct {
+	C    chan [2]uint32
+	Stop func()
+	Join func()
+}
+
+func goParseActiveConnectionStateSignals(ctx context.Context, wg *sync.WaitGroup, sub *unix.DBusSignalSubscription, acPath dbus.ObjectPath, outCh chan [2]uint32) {
+	defer wg.Done()
+	defer sub.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig, ok := <-sub.C:
+			if !ok || sig == nil {
+				return
+			}
+			if sig.Path != acPath || sig.Name != NetworkManagerActiveConnectionInterface+".StateChanged" || len(sig.Body) < 2 {
+				continue
+			}
+			state, ok1 := sig.Body[0].(uint32)
+			reason, ok2 := sig.Body[1].(uint32)
+			if !ok1 || !ok2 {
+				continue
+			}
+			select {
+			case outCh <- [2]uint32{state, reason}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// SubscribeActiveConnectionState reports the state changes of the active connection at acPath, e.g. as returned by ConnectToSSID.
+func SubscribeActiveConnectionState(conn *dbus.Conn, acPath dbus.ObjectPath) (*ActiveConnStateSubscription, error) {
+	matchRule := fmt.Sprintf("type='signal',interface='%s',member='StateChanged',path='%s'", NetworkManagerActiveConnectionInterface, acPath)
+	sub := &unix.DBusSignalSubscription{}
+	err := sub.MakeDBusSignalSubscriptionOnConn(conn, matchRule, 20)
+	if err != nil {
+		return nil, err
+	}
+	outCh := make(chan [2]uint32, 20)
+	wg := &sync.WaitGroup{}
+	ctx, cancel := context.WithCancel(context.Background())
+	wg.Add(1)
+	go goParseActiveConnectionStateSignals(ctx, wg, sub, acPath, outCh)
+	ret := &ActiveConnStateSubscription{
+		C:    outCh,
+		Stop: cancel,
+		Join: wg.Wait,
+	}
+	return ret, nil
+}
+
+// nmConnectionNotActiveError is the D-Bus error NetworkManager replies with when deactivating a connection that isn't active.
+const nmConnectionNotActiveError = "org.freedesktop.NetworkManager.ConnectionNotActive"
+
+// DeactivateConnection takes down the active connection at acPath, keeping its saved connection. A connection that is no longer active gives ErrConnectionNotActive.
+func DeactivateConnection(conn *dbus.Conn, acPath dbus.ObjectPath) error {
+	call := conn.Object(NetworkManagerInterface, NetworkManagerObjectPath).Call(NetworkManagerMethodDeactivateConnection, 0, acPath)
+	var dbusErr dbus.Error
+	if errors.As(call.Err, &dbusErr) && dbusErr.Name == nmConnectionNotActiveError {
+		return fmt.Errorf("%w: %s", ErrConnectionNotActive, acPath)
+	}
+	if call.Err != nil {
+		return fmt.Errorf("error in call to %s: %v", NetworkManagerMethodDeactivateConnection, call.Err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package network
+
+import (
+	"github.com/Potsdam-Sensors/GoLinuxToolkit/unix"
+	"github.com/godbus/dbus/v5"
+)
+
+// WiFi bands, as returned by FreqToBand
+const (
+	Band2_4GHz = "2.4GHz"
+	Band5GHz   = "5GHz"
+	Band6GHz   = "6GHz"
+)
+
+// FreqToChannel returns the WiFi channel number of a frequency in MHz, 0 if it isn't a 2.4, 5 or 6 GHz channel.
+func FreqToChannel(freq uint32) int {
+	switch {
+	case freq == 2484:
+		return 14
+	case freq >= 2412 && freq <= 2472:
+		return int(freq-2407) / 5
+	case freq >= 5000 && freq < 5925:
+		return int(freq-5000) / 5
+	case freq == 5935:
+		// The only 6 GHz channel off the 5950 MHz grid
+		return 2
+	case freq >= 5955 && freq <= 7115:
+		return int(freq-5950) / 5
+	}
+	return 0
+}
+
+// FreqToBand returns the band (Band2_4GHz, Band5GHz or Band6GHz) of a frequency in MHz, "" if it is in none of them.
+func FreqToBand(freq uint32) string {
+	switch {
+	case freq >= 2400 && freq < 2500:
+		return Band2_4GHz
+	case freq >= 5000 && freq < 5925:
+		return Band5GHz
+	case freq >= 5925 && freq <= 7125:
+		return Band6GHz
+	}
+	return ""
+}
+
+/*
+ActiveApInfo describes the radio link to the access point a WiFi device is connected to.
+Frequency is in MHz, Strength in percent and Bitrate, the rate currently negotiated with the
+access point, in kbit/s.
+*/
+type ActiveApInfo struct {
+	Frequency uint32
+	Channel   int
+	Band      string
+	Strength  uint8
+	Bitrate   uint32
+}
+
+// GetActiveApInfo returns the frequency, channel, band and bitrate of the WiFi device's link, nil if it isn't connected.
+func GetActiveApInfo(conn *dbus.Conn, devObj *dbus.BusObject) (*ActiveApInfo, error) {
+	apPath, err := getActiveAccessPoint(devObj)
+	if err != nil {
+		return nil, err
+	}
+	if apPath == "/" {
+		return nil, nil
+	}
+	info := &ActiveApInfo{}
+	info.Frequency, err = unix.GetProperty[uint32](conn, NetworkManagerInterface, NetworkManagerAccessPointInterface, apPath, "Frequency")
+	if err != nil {
+		return nil, err
+	}
+	info.Channel = FreqToChannel(info.Frequency)
+	info.Band = FreqToBand(info.Frequency)
+	info.Strength, err = getAccessPointStrength(conn, apPath)
+	if err != nil {
+		return nil, err
+	}
+	info.Bitrate, err = unix.GetProperty[uint32](conn, NetworkManagerInterface, NetworkManagerWirelessInterface, (*devObj).Path(), "Bitrate")
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
@@ -0,0 +1,58 @@
+package network
+
+import "testing"
+
+func TestFreqToChannel(t *testing.T) {
+	tests := []struct {
+		freq uint32
+		want int
+	}{
+		{2412, 1},
+		{2437, 6},
+		{2472, 13},
+		{2484, 14},
+		{5180, 36},
+		{5500, 100},
+		{5825, 165},
+		{5935, 2},
+		{5955, 1},
+		{6115, 33},
+		{7115, 233},
+		{0, 0},
+		{2400, 0},
+		{2480, 0},
+		{4900, 0},
+		{7135, 0},
+	}
+	for _, tt := range tests {
+		if got := FreqToChannel(tt.freq); got != tt.want {
+			t.Errorf("FreqToChannel(%d) = %d, want %d", tt.freq, got, tt.want)
+		}
+	}
+}
+
+func TestFreqToBand(t *testing.T) {
+	tests := []struct {
+		freq uint32
+		want string
+	}{
+		{2399, ""},
+		{2400, Band2_4GHz},
+		{2484, Band2_4GHz},
+		{2499, Band2_4GHz},
+		{2500, ""},
+		{4999, ""},
+		{5000, Band5GHz},
+		{5180, Band5GHz},
+		{5924, Band5GHz},
+		{5925, Band6GHz},
+		{5955, Band6GHz},
+		{7125, Band6GHz},
+		{7126, ""},
+	}
+	for _, tt := range tests {
+		if got := FreqToBand(tt.freq); got != tt.want {
+			t.Errorf("FreqToBand(%d) = %q, want %q", tt.freq, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package network
+
+import (
+	"github.com/Potsdam-Sensors/GoLinuxToolkit/unix"
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	NetworkManagerMethodCheckpointCreate   = "org.freedesktop.NetworkManager.CheckpointCreate"
+	NetworkManagerMethodCheckpointRollback = "org.freedesktop.NetworkManager.CheckpointRollback"
+	NetworkManagerMethodCheckpointDestroy  = "org.freedesktop.NetworkManager.CheckpointDestroy"
+)
+
+const (
+	NM_ROLLBACK_RESULT_OK                   = 0 // the rollback succeeded
+	NM_ROLLBACK_RESULT_ERR_NO_DEVICE        = 1 // the device no longer exists
+	NM_ROLLBACK_RESULT_ERR_DEVICE_UNMANAGED = 2 // the device is now unmanaged
+	NM_ROLLBACK_RESULT_ERR_FAILED           = 3 // other errors during rollback
+)
+
+var NM_ROLLBACK_RESULT_MAP = map[uint32]string{
+	NM_ROLLBACK_RESULT_OK:                   "OK",
+	NM_ROLLBACK_RESULT_ERR_NO_DEVICE:        "No device",
+	NM_ROLLBACK_RESULT_ERR_DEVICE_UNMANAGED: "Device unmanaged",
+	NM_ROLLBACK_RESULT_ERR_FAILED:           "Failed",
+}
+
+/*
+CreateCheckpoint snapshots the configuration of devices (all devices if empty). Unless it is
+destroyed with DestroyCheckpoint within timeoutSec seconds, NetworkManager rolls back to it on its
+own, so a change that cuts the device off undoes itself. A timeoutSec of 0 never rolls back.
+*/
+func CreateCheckpoint(conn *dbus.Conn, devices []dbus.ObjectPath, timeoutSec uint32) (dbus.ObjectPath, error) {
+	if devices == nil {
+		devices = []dbus.ObjectPath{}
+	}
+	var checkpoint dbus.ObjectPath
+	err := unix.CallMethod(conn, NetworkManagerInterface, NetworkManagerObjectPath, NetworkManagerMethodCheckpointCreate, &checkpoint, devices, timeoutSec, uint32(0))
+	if err != nil {
+		return "", err
+	}
+	return checkpoint, nil
+}
+
+// RollbackCheckpoint restores the checkpoint right away, returning the NM_ROLLBACK_RESULT_* of each device.
+func RollbackCheckpoint(conn *dbus.Conn, checkpoint dbus.ObjectPath) (map[string]uint32, error) {
+	var results map[string]uint32
+	err := unix.CallMethod(conn, NetworkManagerInterface, NetworkManagerObjectPath, NetworkManagerMethodCheckpointRollback, &results, checkpoint)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// DestroyCheckpoint confirms the changes made since the checkpoint, cancelling its automatic rollback.
+func DestroyCheckpoint(conn *dbus.Conn, checkpoint dbus.ObjectPath) error {
+	return unix.CallMethod(conn, NetworkManagerInterface, NetworkManagerObjectPath, NetworkManagerMethodCheckpointDestroy, nil, checkpoint)
+}
@@ -0,0 +1,57 @@
+package network
+
+import (
+	"github.com/Potsdam-Sensors/GoLinuxToolkit/unix"
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	NetworkManagerDnsManagerInterface  = "org.freedesktop.NetworkManager.DnsManager"
+	NetworkManagerDnsManagerObjectPath = dbus.ObjectPath("/org/freedesktop/NetworkManager/DnsManager")
+)
+
+/*
+DNSEntry is the DNS configuration one connection contributes. A lower Priority is preferred,
+Interface is empty for the global configuration and VPN is set for entries from a VPN.
+*/
+type DNSEntry struct {
+	Nameservers []string
+	Domains     []string
+	Interface   string
+	Priority    int32
+	VPN         bool
+}
+
+/*
+DNSConfig is NetworkManager's DNS setup. Mode is how it applies it (e.g. "default",
+"systemd-resolved" or "dnsmasq") and Entries every configuration it merged.
+*/
+type DNSConfig struct {
+	Mode    string
+	Entries []DNSEntry
+}
+
+// GetGlobalDNSConfiguration returns the DNS servers and search domains NetworkManager currently uses, across all connections including VPNs.
+func GetGlobalDNSConfiguration(conn *dbus.Conn) (*DNSConfig, error) {
+	mode, err := unix.GetProperty[string](conn, NetworkManagerInterface, NetworkManagerDnsManagerInterface, NetworkManagerDnsManagerObjectPath, "Mode")
+	if err != nil {
+		return nil, err
+	}
+	raw, err := unix.GetProperty[[]map[string]dbus.Variant](conn, NetworkManagerInterface, NetworkManagerDnsManagerInterface, NetworkManagerDnsManagerObjectPath, "Configuration")
+	if err != nil {
+		return nil, err
+	}
+
+	config := &DNSConfig{Mode: mode, Entries: make([]DNSEntry, 0, len(raw))}
+	for _, entry := range raw {
+		// Keys are only present when set
+		var e DNSEntry
+		e.Nameservers, _ = entry["nameservers"].Value().([]string)
+		e.Domains, _ = entry["domains"].Value().([]string)
+		e.Interface, _ = entry["interface"].Value().(string)
+		e.Priority, _ = entry["priority"].Value().(int32)
+		e.VPN, _ = entry["vpn"].Value().(bool)
+		config.Entries = append(config.Entries, e)
+	}
+	return config, nil
+}
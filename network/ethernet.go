@@ -0,0 +1,58 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+)
+
+/*
+ID names the connection profile, defaulting to "Wired <interface>".
+IPv4 left nil uses DHCP.
+*/
+type EthernetConfig struct {
+	ID   string
+	IPv4 *StaticIPv4Config
+}
+
+func getEthernetSettings(id string, cfg EthernetConfig) (map[string]map[string]dbus.Variant, error) {
+	ipv4, err := getIPv4Settings(cfg.IPv4)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]map[string]dbus.Variant{
+		"802-3-ethernet": {},
+		"connection": {
+			"id":          dbus.MakeVariant(id),
+			"type":        dbus.MakeVariant("802-3-ethernet"),
+			"autoconnect": dbus.MakeVariant(true),
+		},
+		"ipv4": ipv4,
+		"ipv6": {
+			"method": dbus.MakeVariant("auto"),
+		},
+	}, nil
+}
+
+// ConfigureEthernet creates a connection profile for the wired device and activates it, returning the active connection path.
+func ConfigureEthernet(conn *dbus.Conn, devPath dbus.ObjectPath, cfg EthernetConfig) (dbus.ObjectPath, error) {
+	id := cfg.ID
+	if id == "" {
+		devObj := conn.Object(NetworkManagerInterface, devPath)
+		ifName, err := GetDeviceInterfaceName(conn, &devObj)
+		if err != nil {
+			return "", err
+		}
+		id = "Wired " + ifName
+	}
+	settings, err := getEthernetSettings(id, cfg)
+	if err != nil {
+		return "", fmt.Errorf("invalid connection settings: %w", err)
+	}
+
+	_, activeConnectionPath, err := addAndActivateConnection(conn, settings, devPath, "/")
+	if err != nil {
+		return "", fmt.Errorf("failed to add and activate connection: %w", err)
+	}
+	return activeConnectionPath, nil
+}
@@ -0,0 +1,86 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+/*
+FlapEvent reports that a device went up or down more than the threshold given to DetectFlapping
+within its window. Transitions is how often it did, State and Reason are the NM_DEVICE_STATE_*
+and NM_DEVICE_STATE_REASON_* of the last of those transitions.
+*/
+type FlapEvent struct {
+	Time        time.Time
+	Transitions int
+	State       uint32
+	Reason      uint32
+}
+
+func goDetectFlapping(ctx context.Context, stateSub *DeviceStateChangeSubscription, window time.Duration, threshold int, outCh chan FlapEvent) {
+	defer close(outCh)
+	defer stateSub.Join()
+	defer stateSub.Stop()
+
+	var transitions []time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case change := <-stateSub.C:
+			newState, oldState, reason := change[0], change[1], change[2]
+			// Only count the device connecting or losing its connection
+			if newState != NM_DEVICE_STATE_ACTIVATED && oldState != NM_DEVICE_STATE_ACTIVATED {
+				continue
+			}
+			now := time.Now()
+			transitions = append(transitions, now)
+			expired := 0
+			for expired < len(transitions) && now.Sub(transitions[expired]) > window {
+				expired++
+			}
+			transitions = transitions[expired:]
+			if len(transitions) <= threshold {
+				continue
+			}
+
+			event := FlapEvent{Time: now, Transitions: len(transitions), State: newState, Reason: reason}
+			transitions = nil
+			select {
+			case outCh <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+/*
+DetectFlapping watches the device at devPath and sends a FlapEvent when it connects or
+disconnects more than threshold times within window, e.g. because of a bad RF environment.
+After an event the count starts over. The watch runs for the life of the process, see
+DetectFlappingContext to stop it.
+*/
+func DetectFlapping(devPath dbus.ObjectPath, window time.Duration, threshold int) (<-chan FlapEvent, error) {
+	return DetectFlappingContext(context.Background(), devPath, window, threshold)
+}
+
+// DetectFlappingContext is like DetectFlapping but stops watching, closing the channel, once ctx is done.
+func DetectFlappingContext(ctx context.Context, devPath dbus.ObjectPath, window time.Duration, threshold int, opts ...SubscriptionOption) (<-chan FlapEvent, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("invalid flapping window %s", window)
+	}
+	if threshold < 1 {
+		return nil, fmt.Errorf("invalid flapping threshold %d", threshold)
+	}
+	stateSub, err := DeviceStateChangeSubscribe(devPath, opts...)
+	if err != nil {
+		return nil, err
+	}
+	outCh := make(chan FlapEvent, 5)
+	go goDetectFlapping(ctx, stateSub, window, threshold, outCh)
+	return outCh, nil
+}
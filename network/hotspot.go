@@ -0,0 +1,97 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/Potsdam-Sensors/GoLinuxToolkit/unix"
+	"github.com/godbus/dbus/v5"
+)
+
+/*
+Band is "bg" (2.4 GHz, the default) or "a" (5 GHz). Channel 0 lets the driver pick one in the band.
+*/
+type HotspotOptions struct {
+	Band    string
+	Channel uint32
+}
+
+func getHotspotSettings(ssid string, pass string, opts HotspotOptions) (map[string]map[string]dbus.Variant, error) {
+	if ssid == "" || len(ssid) > 32 {
+		return nil, fmt.Errorf("SSID must be 1 to 32 bytes long, got %d", len(ssid))
+	}
+	if pass != "" && (len(pass) < 8 || len(pass) > 63) {
+		return nil, fmt.Errorf("WPA passphrase must be 8 to 63 characters long, got %d", len(pass))
+	}
+	band := opts.Band
+	if band == "" {
+		band = "bg"
+	}
+	if band != "bg" && band != "a" {
+		return nil, fmt.Errorf("invalid band %q, must be \"bg\" or \"a\"", band)
+	}
+
+	wireless := map[string]dbus.Variant{
+		"ssid": dbus.MakeVariant([]byte(ssid)),
+		"mode": dbus.MakeVariant("ap"),
+		"band": dbus.MakeVariant(band),
+	}
+	if opts.Channel != 0 {
+		wireless["channel"] = dbus.MakeVariant(opts.Channel)
+	}
+	settings := map[string]map[string]dbus.Variant{
+		"802-11-wireless": wireless,
+		"802-11-wireless-security": {
+			"key-mgmt": dbus.MakeVariant("wpa-psk"),
+			"psk":      dbus.MakeVariant(pass),
+			"proto":    dbus.MakeVariant([]string{"rsn"}),
+			"pairwise": dbus.MakeVariant([]string{"ccmp"}),
+			"group":    dbus.MakeVariant([]string{"ccmp"}),
+		},
+		"connection": {
+			"id":          dbus.MakeVariant("Hotspot " + ssid),
+			"type":        dbus.MakeVariant("802-11-wireless"),
+			"autoconnect": dbus.MakeVariant(false),
+		},
+		// shared runs a DHCP server and NATs clients out through the other connections
+		"ipv4": {
+			"method": dbus.MakeVariant("shared"),
+		},
+		"ipv6": {
+			"method": dbus.MakeVariant("ignore"),
+		},
+	}
+	if pass == "" {
+		delete(settings, "802-11-wireless-security")
+	}
+	return settings, nil
+}
+
+/*
+StartHotspot makes the WiFi device broadcast its own access point, WPA2 protected by pass or open if
+pass is empty. Clients get addresses over DHCP and share the device's other connections.
+It returns the path of the active connection, to be passed to StopHotspot.
+*/
+func StartHotspot(conn *dbus.Conn, devPath dbus.ObjectPath, ssid string, pass string, opts HotspotOptions) (dbus.ObjectPath, error) {
+	settings, err := getHotspotSettings(ssid, pass, opts)
+	if err != nil {
+		return "", fmt.Errorf("invalid hotspot settings: %w", err)
+	}
+	_, activeConnectionPath, err := addAndActivateConnection(conn, settings, devPath, "/")
+	if err != nil {
+		return "", fmt.Errorf("failed to add and activate hotspot: %w", err)
+	}
+	return activeConnectionPath, nil
+}
+
+// StopHotspot takes down a hotspot started with StartHotspot and deletes its connection profile.
+func StopHotspot(conn *dbus.Conn, activeConnPath dbus.ObjectPath) error {
+	connPath, err := unix.GetProperty[dbus.ObjectPath](conn, NetworkManagerInterface, NetworkManagerActiveConnectionInterface, activeConnPath, "Connection")
+	if err != nil {
+		return err
+	}
+	err = DeactivateConnection(conn, activeConnPath)
+	if err != nil {
+		return err
+	}
+	return DeleteConnection(conn, connPath)
+}
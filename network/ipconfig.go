@@ -0,0 +1,163 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/Potsdam-Sensors/GoLinuxToolkit/unix"
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	NetworkManagerIP4ConfigInterface = "org.freedesktop.NetworkManager.IP4Config"
+	NetworkManagerIP6ConfigInterface = "org.freedesktop.NetworkManager.IP6Config"
+)
+
+/*
+Families without configuration on the device are left empty, as is a missing gateway.
+*/
+type IPConfig struct {
+	IPv4Addresses []net.IPNet
+	IPv4Gateway   net.IP
+	IPv4DNS       []net.IP
+	IPv6Addresses []net.IPNet
+	IPv6Gateway   net.IP
+	IPv6DNS       []net.IP
+}
+
+func parseAddressData(addressData []map[string]dbus.Variant, bits int) ([]net.IPNet, error) {
+	addrs := make([]net.IPNet, 0, len(addressData))
+	for _, data := range addressData {
+		addr, _ := data["address"].Value().(string)
+		prefix, _ := data["prefix"].Value().(uint32)
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid address in AddressData: \"%s\"", addr)
+		}
+		addrs = append(addrs, net.IPNet{IP: ip, Mask: net.CIDRMask(int(prefix), bits)})
+	}
+	return addrs, nil
+}
+
+// readIPConfig reads the addresses and gateway of the IP4Config/IP6Config object at path.
+func readIPConfig(conn *dbus.Conn, path dbus.ObjectPath, iface string, bits int) ([]net.IPNet, net.IP, error) {
+	addressData, err := unix.GetProperty[[]map[string]dbus.Variant](conn, NetworkManagerInterface, iface, path, "AddressData")
+	if err != nil {
+		return nil, nil, err
+	}
+	addrs, err := parseAddressData(addressData, bits)
+	if err != nil {
+		return nil, nil, err
+	}
+	gateway, err := unix.GetProperty[string](conn, NetworkManagerInterface, iface, path, "Gateway")
+	if err != nil {
+		return nil, nil, err
+	}
+	return addrs, net.ParseIP(gateway), nil
+}
+
+// GetDeviceIPConfig returns the addresses, gateways and DNS servers currently configured on the device.
+func GetDeviceIPConfig(conn *dbus.Conn, devObj *dbus.BusObject) (*IPConfig, error) {
+	devPath := (*devObj).Path()
+	cfg := &IPConfig{}
+
+	ip4Path, err := unix.GetProperty[dbus.ObjectPath](conn, NetworkManagerInterface, NetworkManagerDeviceInterface, devPath, "Ip4Config")
+	if err != nil {
+		return nil, err
+	}
+	if ip4Path != "/" {
+		cfg.IPv4Addresses, cfg.IPv4Gateway, err = readIPConfig(conn, ip4Path, NetworkManagerIP4ConfigInterface, 32)
+		if err != nil {
+			return nil, err
+		}
+		nameservers, err := unix.GetProperty[[]map[string]dbus.Variant](conn, NetworkManagerInterface, NetworkManagerIP4ConfigInterface, ip4Path, "NameserverData")
+		if err != nil {
+			return nil, err
+		}
+		for _, data := range nameservers {
+			addr, _ := data["address"].Value().(string)
+			if ip := net.ParseIP(addr); ip != nil {
+				cfg.IPv4DNS = append(cfg.IPv4DNS, ip)
+			}
+		}
+	}
+
+	ip6Path, err := unix.GetProperty[dbus.ObjectPath](conn, NetworkManagerInterface, NetworkManagerDeviceInterface, devPath, "Ip6Config")
+	if err != nil {
+		return nil, err
+	}
+	if ip6Path != "/" {
+		cfg.IPv6Addresses, cfg.IPv6Gateway, err = readIPConfig(conn, ip6Path, NetworkManagerIP6ConfigInterface, 128)
+		if err != nil {
+			return nil, err
+		}
+		// IP6Config has no NameserverData, only the raw 16 byte addresses
+		nameservers, err := unix.GetProperty[[][]byte](conn, NetworkManagerInterface, NetworkManagerIP6ConfigInterface, ip6Path, "Nameservers")
+		if err != nil {
+			return nil, err
+		}
+		for _, raw := range nameservers {
+			if len(raw) == net.IPv6len {
+				cfg.IPv6DNS = append(cfg.IPv6DNS, net.IP(raw))
+			}
+		}
+	}
+	return cfg, nil
+}
+
+/*
+GetInterfaceAddresses returns the IPv4 and IPv6 addresses of the interface. They come from
+NetworkManager when it is running and has an IP configuration for the interface, otherwise
+straight from the kernel, so this works without NetworkManager and for devices it doesn't manage.
+*/
+func GetInterfaceAddresses(ifName string) ([]net.IPNet, error) {
+	conn, err := dbus.ConnectSystemBus()
+	if err != nil {
+		// No system bus means no NetworkManager either
+		return getKernelInterfaceAddresses(ifName)
+	}
+	defer conn.Close()
+	if IsNetworkManagerAvailable(conn) {
+		addrs, err := getNetworkManagerInterfaceAddresses(conn, ifName)
+		if err != nil && !errors.Is(err, ErrDeviceNotFound) {
+			getLogger().Warn("Failed to get addresses from NetworkManager, reading them from the kernel", "interface", ifName, "err", err)
+		}
+		// Unmanaged devices have no Ip4Config/Ip6Config ("/"), so no addresses from NetworkManager
+		if err == nil && len(addrs) > 0 {
+			return addrs, nil
+		}
+	}
+	return getKernelInterfaceAddresses(ifName)
+}
+
+func getNetworkManagerInterfaceAddresses(conn *dbus.Conn, ifName string) ([]net.IPNet, error) {
+	devPath, err := GetDevicePathFromInterfaceName(conn, ifName)
+	if err != nil {
+		return nil, err
+	}
+	devObj := conn.Object(NetworkManagerInterface, devPath)
+	cfg, err := GetDeviceIPConfig(conn, &devObj)
+	if err != nil {
+		return nil, err
+	}
+	return append(cfg.IPv4Addresses, cfg.IPv6Addresses...), nil
+}
+
+func getKernelInterfaceAddresses(ifName string) ([]net.IPNet, error) {
+	iface, err := net.InterfaceByName(ifName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDeviceNotFound, err)
+	}
+	ifAddrs, err := iface.Addrs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get addresses of %s: %v", ifName, err)
+	}
+	addrs := make([]net.IPNet, 0, len(ifAddrs))
+	for _, addr := range ifAddrs {
+		if ipNet, ok := addr.(*net.IPNet); ok {
+			addrs = append(addrs, *ipNet)
+		}
+	}
+	return addrs, nil
+}
@@ -0,0 +1,50 @@
+package network
+
+import (
+	"log/slog"
+	"sync/atomic"
+)
+
+var logger atomic.Pointer[slog.Logger]
+
+// SetLogger sets where the package logs warnings, including from running subscriptions not given WithLogger. nil restores slog.Default().
+func SetLogger(l *slog.Logger) {
+	logger.Store(l)
+}
+
+func getLogger() *slog.Logger {
+	if l := logger.Load(); l != nil {
+		return l
+	}
+	return slog.Default()
+}
+
+// SubscriptionOption configures a subscription, see WithLogger.
+type SubscriptionOption func(*subscriptionOptions)
+
+type subscriptionOptions struct {
+	logger *slog.Logger
+}
+
+// WithLogger makes a subscription log its warnings to l instead of the package's logger.
+func WithLogger(l *slog.Logger) SubscriptionOption {
+	return func(o *subscriptionOptions) {
+		o.logger = l
+	}
+}
+
+func newSubscriptionOptions(opts []SubscriptionOption) subscriptionOptions {
+	var o subscriptionOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// log returns the subscription's logger, falling back to the package's so SetLogger still applies.
+func (o subscriptionOptions) log() *slog.Logger {
+	if o.logger != nil {
+		return o.logger
+	}
+	return getLogger()
+}
@@ -0,0 +1,25 @@
+package network
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func TestSubscriptionLogger(t *testing.T) {
+	pkg := slog.New(slog.NewTextHandler(io.Discard, nil))
+	own := slog.New(slog.NewTextHandler(io.Discard, nil))
+	SetLogger(pkg)
+	defer SetLogger(nil)
+
+	if got := newSubscriptionOptions(nil).log(); got != pkg {
+		t.Errorf("subscription without WithLogger logs to %p, want the package logger %p", got, pkg)
+	}
+	if got := newSubscriptionOptions([]SubscriptionOption{WithLogger(own)}).log(); got != own {
+		t.Errorf("subscription with WithLogger logs to %p, want %p", got, own)
+	}
+	SetLogger(nil)
+	if got := newSubscriptionOptions(nil).log(); got != slog.Default() {
+		t.Errorf("subscription without any logger logs to %p, want slog.Default()", got)
+	}
+}
@@ -2,11 +2,15 @@ package network
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
-	"log"
+	"net"
+	"strings"
 	"sync"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/Potsdam-Sensors/GoLinuxToolkit/unix"
 	"github.com/godbus/dbus/v5"
@@ -15,6 +19,7 @@ import (
 const (
 	MethodDbusGetProperty  = "org.freedesktop.DBus.Properties.Get"
 	MethodDbusAddMatchRule = "org.freedesktop.DBus.AddMatch"
+	MethodDbusNameHasOwner = "org.freedesktop.DBus.NameHasOwner"
 
 	SystemdInterface  = "org.freedesktop.systemd1"
 	SystemdObjectPath = dbus.ObjectPath("/org/freedesktop/systemd1")
@@ -26,12 +31,22 @@ const (
 	NetworkManagerMethodGetState           = "org.freedesktop.NetworkManager.state"
 	NetworkManagerMethodCheckConnectivity  = "org.freedesktop.NetworkManager.CheckConnectivity"
 	NetworkManagerMethodGetDeviceFromIFace = "org.freedesktop.NetworkManager.GetDeviceByIpIface"
+	NetworkManagerMethodGetAllDevices      = "org.freedesktop.NetworkManager.GetAllDevices"
+	NetworkManagerMethodEnable             = "org.freedesktop.NetworkManager.Enable"
 	NetworkManagerMethodWirelessSSIDScan   = "org.freedesktop.NetworkManager.Device.Wireless.RequestScan"
 	NetworkManagerMethodGetSSIDs           = "org.freedesktop.NetworkManager.Device.Wireless.GetAccessPoints"
+	NetworkManagerAccessPointInterface     = "org.freedesktop.NetworkManager.AccessPoint"
+	NetworkManagerWirelessInterface        = "org.freedesktop.NetworkManager.Device.Wireless"
+	NetworkManagerWiredInterface           = "org.freedesktop.NetworkManager.Device.Wired"
+	NetworkManagerMethodDeviceDisconnect   = "org.freedesktop.NetworkManager.Device.Disconnect"
 )
 
 const (
 	DeviceStateChangedSignal = NetworkManagerDeviceInterface + ".StateChanged"
+
+	DbusPropertiesInterface     = "org.freedesktop.DBus.Properties"
+	DbusPropertiesChangedMember = "PropertiesChanged"
+	DbusPropertiesChangedSignal = DbusPropertiesInterface + "." + DbusPropertiesChangedMember
 )
 
 const (
@@ -104,10 +119,284 @@ var NM_DEVICE_STATE_MAP = map[uint32]string{
 	NM_DEVICE_STATE_FAILED:       "Failed",
 }
 
+const (
+	NM_DEVICE_TYPE_UNKNOWN       = 0  // unknown device
+	NM_DEVICE_TYPE_ETHERNET      = 1  // a wired ethernet device
+	NM_DEVICE_TYPE_WIFI          = 2  // an 802.11 WiFi device
+	NM_DEVICE_TYPE_BT            = 5  // a Bluetooth device supporting PAN or DUN access protocols
+	NM_DEVICE_TYPE_OLPC_MESH     = 6  // an OLPC XO mesh networking device
+	NM_DEVICE_TYPE_WIMAX         = 7  // an 802.16e Mobile WiMAX broadband device
+	NM_DEVICE_TYPE_MODEM         = 8  // a modem supporting analog telephone, CDMA/EVDO, GSM/UMTS, or LTE network access protocols
+	NM_DEVICE_TYPE_INFINIBAND    = 9  // an IP-over-InfiniBand device
+	NM_DEVICE_TYPE_BOND          = 10 // a bond master interface
+	NM_DEVICE_TYPE_VLAN          = 11 // an 802.1Q VLAN interface
+	NM_DEVICE_TYPE_ADSL          = 12 // ADSL modem
+	NM_DEVICE_TYPE_BRIDGE        = 13 // a bridge master interface
+	NM_DEVICE_TYPE_GENERIC       = 14 // generic support for unrecognized device types
+	NM_DEVICE_TYPE_TEAM          = 15 // a team master interface
+	NM_DEVICE_TYPE_TUN           = 16 // a TUN or TAP interface
+	NM_DEVICE_TYPE_IP_TUNNEL     = 17 // a IP tunnel interface
+	NM_DEVICE_TYPE_MACVLAN       = 18 // a MACVLAN interface
+	NM_DEVICE_TYPE_VXLAN         = 19 // a VXLAN interface
+	NM_DEVICE_TYPE_VETH          = 20 // a VETH interface
+	NM_DEVICE_TYPE_MACSEC        = 21 // a MACsec interface
+	NM_DEVICE_TYPE_DUMMY         = 22 // a dummy interface
+	NM_DEVICE_TYPE_PPP           = 23 // a PPP interface
+	NM_DEVICE_TYPE_OVS_INTERFACE = 24 // a Open vSwitch interface
+	NM_DEVICE_TYPE_OVS_PORT      = 25 // a Open vSwitch port
+	NM_DEVICE_TYPE_OVS_BRIDGE    = 26 // a Open vSwitch bridge
+	NM_DEVICE_TYPE_WPAN          = 27 // a IEEE 802.15.4 (WPAN) MAC Layer Device
+	NM_DEVICE_TYPE_6LOWPAN       = 28 // 6LoWPAN interface
+	NM_DEVICE_TYPE_WIREGUARD     = 29 // a WireGuard interface
+	NM_DEVICE_TYPE_WIFI_P2P      = 30 // an 802.11 Wi-Fi P2P device
+	NM_DEVICE_TYPE_VRF           = 31 // a VRF (Virtual Routing and Forwarding) interface
+	NM_DEVICE_TYPE_LOOPBACK      = 32 // a loopback interface
+)
+
+var NM_DEVICE_TYPE_MAP = map[uint32]string{
+	NM_DEVICE_TYPE_UNKNOWN:       "Unknown",
+	NM_DEVICE_TYPE_ETHERNET:      "Ethernet",
+	NM_DEVICE_TYPE_WIFI:          "WiFi",
+	NM_DEVICE_TYPE_BT:            "Bluetooth",
+	NM_DEVICE_TYPE_OLPC_MESH:     "OLPC Mesh",
+	NM_DEVICE_TYPE_WIMAX:         "WiMAX",
+	NM_DEVICE_TYPE_MODEM:         "Modem",
+	NM_DEVICE_TYPE_INFINIBAND:    "InfiniBand",
+	NM_DEVICE_TYPE_BOND:          "Bond",
+	NM_DEVICE_TYPE_VLAN:          "VLAN",
+	NM_DEVICE_TYPE_ADSL:          "ADSL",
+	NM_DEVICE_TYPE_BRIDGE:        "Bridge",
+	NM_DEVICE_TYPE_GENERIC:       "Generic",
+	NM_DEVICE_TYPE_TEAM:          "Team",
+	NM_DEVICE_TYPE_TUN:           "TUN",
+	NM_DEVICE_TYPE_IP_TUNNEL:     "IP Tunnel",
+	NM_DEVICE_TYPE_MACVLAN:       "MACVLAN",
+	NM_DEVICE_TYPE_VXLAN:         "VXLAN",
+	NM_DEVICE_TYPE_VETH:          "VETH",
+	NM_DEVICE_TYPE_MACSEC:        "MACsec",
+	NM_DEVICE_TYPE_DUMMY:         "Dummy",
+	NM_DEVICE_TYPE_PPP:           "PPP",
+	NM_DEVICE_TYPE_OVS_INTERFACE: "OVS Interface",
+	NM_DEVICE_TYPE_OVS_PORT:      "OVS Port",
+	NM_DEVICE_TYPE_OVS_BRIDGE:    "OVS Bridge",
+	NM_DEVICE_TYPE_WPAN:          "WPAN",
+	NM_DEVICE_TYPE_6LOWPAN:       "6LoWPAN",
+	NM_DEVICE_TYPE_WIREGUARD:     "WireGuard",
+	NM_DEVICE_TYPE_WIFI_P2P:      "WiFi P2P",
+	NM_DEVICE_TYPE_VRF:           "VRF",
+	NM_DEVICE_TYPE_LOOPBACK:      "Loopback",
+}
+
+const (
+	NM_802_11_AP_FLAGS_NONE    = 0x0 // access point has no special capabilities
+	NM_802_11_AP_FLAGS_PRIVACY = 0x1 // access point requires authentication and encryption (usually means WEP)
+	NM_802_11_AP_FLAGS_WPS     = 0x2 // access point supports some WPS method
+	NM_802_11_AP_FLAGS_WPS_PBC = 0x4 // access point supports push-button WPS
+	NM_802_11_AP_FLAGS_WPS_PIN = 0x8 // access point supports PIN-based WPS
+)
+
+const (
+	NM_802_11_AP_SEC_NONE                     = 0x0    // the access point has no special security requirements
+	NM_802_11_AP_SEC_PAIR_WEP40               = 0x1    // 40/64-bit WEP is supported for pairwise/unicast encryption
+	NM_802_11_AP_SEC_PAIR_WEP104              = 0x2    // 104/128-bit WEP is supported for pairwise/unicast encryption
+	NM_802_11_AP_SEC_PAIR_TKIP                = 0x4    // TKIP is supported for pairwise/unicast encryption
+	NM_802_11_AP_SEC_PAIR_CCMP                = 0x8    // AES/CCMP is supported for pairwise/unicast encryption
+	NM_802_11_AP_SEC_GROUP_WEP40              = 0x10   // 40/64-bit WEP is supported for group/broadcast encryption
+	NM_802_11_AP_SEC_GROUP_WEP104             = 0x20   // 104/128-bit WEP is supported for group/broadcast encryption
+	NM_802_11_AP_SEC_GROUP_TKIP               = 0x40   // TKIP is supported for group/broadcast encryption
+	NM_802_11_AP_SEC_GROUP_CCMP               = 0x80   // AES/CCMP is supported for group/broadcast encryption
+	NM_802_11_AP_SEC_KEY_MGMT_PSK             = 0x100  // WPA/RSN Pre-Shared Key encryption is supported
+	NM_802_11_AP_SEC_KEY_MGMT_802_1X          = 0x200  // 802.1x authentication and key management is supported
+	NM_802_11_AP_SEC_KEY_MGMT_SAE             = 0x400  // WPA/RSN Simultaneous Authentication of Equals is supported
+	NM_802_11_AP_SEC_KEY_MGMT_OWE             = 0x800  // WPA/RSN Opportunistic Wireless Encryption is supported
+	NM_802_11_AP_SEC_KEY_MGMT_OWE_TM          = 0x1000 // WPA/RSN Opportunistic Wireless Encryption transition mode is supported
+	NM_802_11_AP_SEC_KEY_MGMT_EAP_SUITE_B_192 = 0x2000 // WPA3 Enterprise Suite-B 192 bit mode is supported
+)
+
+const (
+	NM_DEVICE_STATE_REASON_NONE                           = 0  // no reason given
+	NM_DEVICE_STATE_REASON_UNKNOWN                        = 1  // unknown error
+	NM_DEVICE_STATE_REASON_NOW_MANAGED                    = 2  // device is now managed
+	NM_DEVICE_STATE_REASON_NOW_UNMANAGED                  = 3  // device is now unmanaged
+	NM_DEVICE_STATE_REASON_CONFIG_FAILED                  = 4  // the device could not be readied for configuration
+	NM_DEVICE_STATE_REASON_IP_CONFIG_UNAVAILABLE          = 5  // IP configuration could not be reserved (no available address, timeout, etc)
+	NM_DEVICE_STATE_REASON_IP_CONFIG_EXPIRED              = 6  // the IP config is no longer valid
+	NM_DEVICE_STATE_REASON_NO_SECRETS                     = 7  // secrets were required, but not provided
+	NM_DEVICE_STATE_REASON_SUPPLICANT_DISCONNECT          = 8  // 802.1x supplicant disconnected
+	NM_DEVICE_STATE_REASON_SUPPLICANT_CONFIG_FAILED       = 9  // 802.1x supplicant configuration failed
+	NM_DEVICE_STATE_REASON_SUPPLICANT_FAILED              = 10 // 802.1x supplicant failed
+	NM_DEVICE_STATE_REASON_SUPPLICANT_TIMEOUT             = 11 // 802.1x supplicant took too long to authenticate
+	NM_DEVICE_STATE_REASON_PPP_START_FAILED               = 12 // PPP service failed to start
+	NM_DEVICE_STATE_REASON_PPP_DISCONNECT                 = 13 // PPP service disconnected
+	NM_DEVICE_STATE_REASON_PPP_FAILED                     = 14 // PPP failed
+	NM_DEVICE_STATE_REASON_DHCP_START_FAILED              = 15 // DHCP client failed to start
+	NM_DEVICE_STATE_REASON_DHCP_ERROR                     = 16 // DHCP client error
+	NM_DEVICE_STATE_REASON_DHCP_FAILED                    = 17 // DHCP client failed
+	NM_DEVICE_STATE_REASON_SHARED_START_FAILED            = 18 // shared connection service failed to start
+	NM_DEVICE_STATE_REASON_SHARED_FAILED                  = 19 // shared connection service failed
+	NM_DEVICE_STATE_REASON_AUTOIP_START_FAILED            = 20 // AutoIP service failed to start
+	NM_DEVICE_STATE_REASON_AUTOIP_ERROR                   = 21 // AutoIP service error
+	NM_DEVICE_STATE_REASON_AUTOIP_FAILED                  = 22 // AutoIP service failed
+	NM_DEVICE_STATE_REASON_MODEM_BUSY                     = 23 // the line is busy
+	NM_DEVICE_STATE_REASON_MODEM_NO_DIAL_TONE             = 24 // no dial tone
+	NM_DEVICE_STATE_REASON_MODEM_NO_CARRIER               = 25 // no carrier could be established
+	NM_DEVICE_STATE_REASON_MODEM_DIAL_TIMEOUT             = 26 // the dialing request timed out
+	NM_DEVICE_STATE_REASON_MODEM_DIAL_FAILED              = 27 // the dialing attempt failed
+	NM_DEVICE_STATE_REASON_MODEM_INIT_FAILED              = 28 // modem initialization failed
+	NM_DEVICE_STATE_REASON_GSM_APN_FAILED                 = 29 // failed to select the specified APN
+	NM_DEVICE_STATE_REASON_GSM_REGISTRATION_NOT_SEARCHING = 30 // not searching for networks
+	NM_DEVICE_STATE_REASON_GSM_REGISTRATION_DENIED        = 31 // network registration denied
+	NM_DEVICE_STATE_REASON_GSM_REGISTRATION_TIMEOUT       = 32 // network registration timed out
+	NM_DEVICE_STATE_REASON_GSM_REGISTRATION_FAILED        = 33 // failed to register with the requested network
+	NM_DEVICE_STATE_REASON_GSM_PIN_CHECK_FAILED           = 34 // PIN check failed
+	NM_DEVICE_STATE_REASON_FIRMWARE_MISSING               = 35 // necessary firmware for the device may be missing
+	NM_DEVICE_STATE_REASON_REMOVED                        = 36 // the device was removed
+	NM_DEVICE_STATE_REASON_SLEEPING                       = 37 // NetworkManager went to sleep
+	NM_DEVICE_STATE_REASON_CONNECTION_REMOVED             = 38 // the device's active connection disappeared
+	NM_DEVICE_STATE_REASON_USER_REQUESTED                 = 39 // device disconnected by user or client
+	NM_DEVICE_STATE_REASON_CARRIER                        = 40 // carrier/link changed
+	NM_DEVICE_STATE_REASON_CONNECTION_ASSUMED             = 41 // the device's existing connection was assumed
+	NM_DEVICE_STATE_REASON_SUPPLICANT_AVAILABLE           = 42 // the supplicant is now available
+	NM_DEVICE_STATE_REASON_MODEM_NOT_FOUND                = 43 // the modem could not be found
+	NM_DEVICE_STATE_REASON_BT_FAILED                      = 44 // the Bluetooth connection failed or timed out
+	NM_DEVICE_STATE_REASON_GSM_SIM_NOT_INSERTED           = 45 // GSM Modem's SIM Card not inserted
+	NM_DEVICE_STATE_REASON_GSM_SIM_PIN_REQUIRED           = 46 // GSM Modem's SIM Pin required
+	NM_DEVICE_STATE_REASON_GSM_SIM_PUK_REQUIRED           = 47 // GSM Modem's SIM Puk required
+	NM_DEVICE_STATE_REASON_GSM_SIM_WRONG                  = 48 // GSM Modem's SIM wrong
+	NM_DEVICE_STATE_REASON_INFINIBAND_MODE                = 49 // InfiniBand device does not support connected mode
+	NM_DEVICE_STATE_REASON_DEPENDENCY_FAILED              = 50 // a dependency of the connection failed
+	NM_DEVICE_STATE_REASON_BR2684_FAILED                  = 51 // problem with the RFC 2684 Ethernet over ADSL bridge
+	NM_DEVICE_STATE_REASON_MODEM_MANAGER_UNAVAILABLE      = 52 // ModemManager not running
+	NM_DEVICE_STATE_REASON_SSID_NOT_FOUND                 = 53 // the WiFi network could not be found
+	NM_DEVICE_STATE_REASON_SECONDARY_CONNECTION_FAILED    = 54 // a secondary connection of the base connection failed
+	NM_DEVICE_STATE_REASON_DCB_FCOE_FAILED                = 55 // DCB or FCoE setup failed
+	NM_DEVICE_STATE_REASON_TEAMD_CONTROL_FAILED           = 56 // teamd control failed
+	NM_DEVICE_STATE_REASON_MODEM_FAILED                   = 57 // modem failed or no longer available
+	NM_DEVICE_STATE_REASON_MODEM_AVAILABLE                = 58 // modem now ready and available
+	NM_DEVICE_STATE_REASON_SIM_PIN_INCORRECT              = 59 // SIM PIN was incorrect
+	NM_DEVICE_STATE_REASON_NEW_ACTIVATION                 = 60 // new connection activation was enqueued
+	NM_DEVICE_STATE_REASON_PARENT_CHANGED                 = 61 // the device's parent changed
+	NM_DEVICE_STATE_REASON_PARENT_MANAGED_CHANGED         = 62 // the device parent's management changed
+	NM_DEVICE_STATE_REASON_OVSDB_FAILED                   = 63 // problem communicating with Open vSwitch database
+	NM_DEVICE_STATE_REASON_IP_ADDRESS_DUPLICATE           = 64 // a duplicate IP address was detected
+	NM_DEVICE_STATE_REASON_IP_METHOD_UNSUPPORTED          = 65 // the selected IP method is not supported
+	NM_DEVICE_STATE_REASON_SRIOV_CONFIGURATION_FAILED     = 66 // configuration of SR-IOV parameters failed
+	NM_DEVICE_STATE_REASON_PEER_NOT_FOUND                 = 67 // the WiFi P2P peer could not be found
+)
+
+var NM_DEVICE_STATE_REASON_MAP = map[uint32]string{
+	NM_DEVICE_STATE_REASON_NONE:                           "No reason given",
+	NM_DEVICE_STATE_REASON_UNKNOWN:                        "Unknown error",
+	NM_DEVICE_STATE_REASON_NOW_MANAGED:                    "Now managed",
+	NM_DEVICE_STATE_REASON_NOW_UNMANAGED:                  "Now unmanaged",
+	NM_DEVICE_STATE_REASON_CONFIG_FAILED:                  "Config failed",
+	NM_DEVICE_STATE_REASON_IP_CONFIG_UNAVAILABLE:          "IP config unavailable",
+	NM_DEVICE_STATE_REASON_IP_CONFIG_EXPIRED:              "IP config expired",
+	NM_DEVICE_STATE_REASON_NO_SECRETS:                     "No secrets",
+	NM_DEVICE_STATE_REASON_SUPPLICANT_DISCONNECT:          "Supplicant disconnected",
+	NM_DEVICE_STATE_REASON_SUPPLICANT_CONFIG_FAILED:       "Supplicant config failed",
+	NM_DEVICE_STATE_REASON_SUPPLICANT_FAILED:              "Supplicant failed",
+	NM_DEVICE_STATE_REASON_SUPPLICANT_TIMEOUT:             "Supplicant timeout",
+	NM_DEVICE_STATE_REASON_PPP_START_FAILED:               "PPP start failed",
+	NM_DEVICE_STATE_REASON_PPP_DISCONNECT:                 "PPP disconnected",
+	NM_DEVICE_STATE_REASON_PPP_FAILED:                     "PPP failed",
+	NM_DEVICE_STATE_REASON_DHCP_START_FAILED:              "DHCP start failed",
+	NM_DEVICE_STATE_REASON_DHCP_ERROR:                     "DHCP error",
+	NM_DEVICE_STATE_REASON_DHCP_FAILED:                    "DHCP failed",
+	NM_DEVICE_STATE_REASON_SHARED_START_FAILED:            "Shared start failed",
+	NM_DEVICE_STATE_REASON_SHARED_FAILED:                  "Shared failed",
+	NM_DEVICE_STATE_REASON_AUTOIP_START_FAILED:            "AutoIP start failed",
+	NM_DEVICE_STATE_REASON_AUTOIP_ERROR:                   "AutoIP error",
+	NM_DEVICE_STATE_REASON_AUTOIP_FAILED:                  "AutoIP failed",
+	NM_DEVICE_STATE_REASON_MODEM_BUSY:                     "Modem busy",
+	NM_DEVICE_STATE_REASON_MODEM_NO_DIAL_TONE:             "Modem no dial tone",
+	NM_DEVICE_STATE_REASON_MODEM_NO_CARRIER:               "Modem no carrier",
+	NM_DEVICE_STATE_REASON_MODEM_DIAL_TIMEOUT:             "Modem dial timeout",
+	NM_DEVICE_STATE_REASON_MODEM_DIAL_FAILED:              "Modem dial failed",
+	NM_DEVICE_STATE_REASON_MODEM_INIT_FAILED:              "Modem init failed",
+	NM_DEVICE_STATE_REASON_GSM_APN_FAILED:                 "GSM APN failed",
+	NM_DEVICE_STATE_REASON_GSM_REGISTRATION_NOT_SEARCHING: "GSM registration not searching",
+	NM_DEVICE_STATE_REASON_GSM_REGISTRATION_DENIED:        "GSM registration denied",
+	NM_DEVICE_STATE_REASON_GSM_REGISTRATION_TIMEOUT:       "GSM registration timeout",
+	NM_DEVICE_STATE_REASON_GSM_REGISTRATION_FAILED:        "GSM registration failed",
+	NM_DEVICE_STATE_REASON_GSM_PIN_CHECK_FAILED:           "GSM PIN check failed",
+	NM_DEVICE_STATE_REASON_FIRMWARE_MISSING:               "Firmware missing",
+	NM_DEVICE_STATE_REASON_REMOVED:                        "Removed",
+	NM_DEVICE_STATE_REASON_SLEEPING:                       "Sleeping",
+	NM_DEVICE_STATE_REASON_CONNECTION_REMOVED:             "Connection removed",
+	NM_DEVICE_STATE_REASON_USER_REQUESTED:                 "User requested",
+	NM_DEVICE_STATE_REASON_CARRIER:                        "Carrier changed",
+	NM_DEVICE_STATE_REASON_CONNECTION_ASSUMED:             "Connection assumed",
+	NM_DEVICE_STATE_REASON_SUPPLICANT_AVAILABLE:           "Supplicant available",
+	NM_DEVICE_STATE_REASON_MODEM_NOT_FOUND:                "Modem not found",
+	NM_DEVICE_STATE_REASON_BT_FAILED:                      "Bluetooth failed",
+	NM_DEVICE_STATE_REASON_GSM_SIM_NOT_INSERTED:           "GSM SIM not inserted",
+	NM_DEVICE_STATE_REASON_GSM_SIM_PIN_REQUIRED:           "GSM SIM PIN required",
+	NM_DEVICE_STATE_REASON_GSM_SIM_PUK_REQUIRED:           "GSM SIM PUK required",
+	NM_DEVICE_STATE_REASON_GSM_SIM_WRONG:                  "GSM SIM wrong",
+	NM_DEVICE_STATE_REASON_INFINIBAND_MODE:                "InfiniBand mode",
+	NM_DEVICE_STATE_REASON_DEPENDENCY_FAILED:              "Dependency failed",
+	NM_DEVICE_STATE_REASON_BR2684_FAILED:                  "BR2684 failed",
+	NM_DEVICE_STATE_REASON_MODEM_MANAGER_UNAVAILABLE:      "ModemManager unavailable",
+	NM_DEVICE_STATE_REASON_SSID_NOT_FOUND:                 "SSID not found",
+	NM_DEVICE_STATE_REASON_SECONDARY_CONNECTION_FAILED:    "Secondary connection failed",
+	NM_DEVICE_STATE_REASON_DCB_FCOE_FAILED:                "DCB/FCoE failed",
+	NM_DEVICE_STATE_REASON_TEAMD_CONTROL_FAILED:           "teamd control failed",
+	NM_DEVICE_STATE_REASON_MODEM_FAILED:                   "Modem failed",
+	NM_DEVICE_STATE_REASON_MODEM_AVAILABLE:                "Modem available",
+	NM_DEVICE_STATE_REASON_SIM_PIN_INCORRECT:              "SIM PIN incorrect",
+	NM_DEVICE_STATE_REASON_NEW_ACTIVATION:                 "New activation",
+	NM_DEVICE_STATE_REASON_PARENT_CHANGED:                 "Parent changed",
+	NM_DEVICE_STATE_REASON_PARENT_MANAGED_CHANGED:         "Parent managed changed",
+	NM_DEVICE_STATE_REASON_OVSDB_FAILED:                   "OVSDB failed",
+	NM_DEVICE_STATE_REASON_IP_ADDRESS_DUPLICATE:           "IP address duplicate",
+	NM_DEVICE_STATE_REASON_IP_METHOD_UNSUPPORTED:          "IP method unsupported",
+	NM_DEVICE_STATE_REASON_SRIOV_CONFIGURATION_FAILED:     "SR-IOV configuration failed",
+	NM_DEVICE_STATE_REASON_PEER_NOT_FOUND:                 "Peer not found",
+}
+
+// Errors that callers can check for with errors.Is, they are wrapped with details of the failure.
+var (
+	ErrNeedAuth            = errors.New("connection needs authentication, the password is likely wrong")
+	ErrActivationFailed    = errors.New("connection activation failed")
+	ErrSSIDNotFound        = errors.New("SSID not found")
+	ErrDeviceNotFound      = errors.New("device not found")
+	ErrNoPrimaryConnection = errors.New("no primary connection")
+	ErrConnectionNotActive = errors.New("connection not active")
+)
+
+// nmUnknownDeviceError is the D-Bus error NetworkManager replies with for a device it doesn't know.
+const nmUnknownDeviceError = "org.freedesktop.NetworkManager.UnknownDevice"
+
+func mapName(m map[uint32]string, value uint32) string {
+	name, ok := m[value]
+	if !ok {
+		return fmt.Sprintf("Invalid (%d)", value)
+	}
+	return name
+}
+
+// FormatDeviceStateChange formats a DeviceStateChangeSubscription event, e.g. "Need Auth -> Failed (reason: No secrets)".
+func FormatDeviceStateChange(change [3]uint32) string {
+	return fmt.Sprintf("%s -> %s (reason: %s)", mapName(NM_DEVICE_STATE_MAP, change[1]), mapName(NM_DEVICE_STATE_MAP, change[0]), mapName(NM_DEVICE_STATE_REASON_MAP, change[2]))
+}
+
 func getNetworkManagerObject(conn *dbus.Conn) *dbus.BusObject {
 	nm := conn.Object(NetworkManagerInterface, NetworkManagerObjectPath)
 	return &nm
 }
+
+// IsNetworkManagerAvailable reports whether NetworkManager is running on the bus, i.e. whether the other functions of this package can work.
+func IsNetworkManagerAvailable(conn *dbus.Conn) bool {
+	var hasOwner bool
+	err := conn.BusObject().Call(MethodDbusNameHasOwner, 0, NetworkManagerInterface).Store(&hasOwner)
+	if err != nil {
+		getLogger().Warn("Failed to check for NetworkManager on the bus", "err", err)
+		return false
+	}
+	return hasOwner
+}
+
 func GetNetworkManagerState(conn *dbus.Conn) (uint32, error) {
 	nmObj := getNetworkManagerObject(conn)
 	if nmObj == nil {
@@ -142,12 +431,42 @@ func GetNetworkManagerConnectivity(conn *dbus.Conn) (uint32, error) {
 	return state, nil
 }
 
-func getDevicesFromConnection(connObj *dbus.BusObject) ([]dbus.ObjectPath, error) {
-	connActiveInterface := "org.freedesktop.NetworkManager.Connection.Active"
+// GetNetworkingEnabled reports whether networking as a whole is enabled, when it isn't no device can connect.
+func GetNetworkingEnabled(conn *dbus.Conn) (bool, error) {
+	return unix.GetProperty[bool](conn, NetworkManagerInterface, NetworkManagerInterface, NetworkManagerObjectPath, "NetworkingEnabled")
+}
+
+// SetNetworkingEnabled enables or disables all networking, disabling it deactivates every connection.
+func SetNetworkingEnabled(conn *dbus.Conn, enabled bool) error {
+	nmObj := getNetworkManagerObject(conn)
+	call := (*nmObj).Call(NetworkManagerMethodEnable, 0, enabled)
+	if call.Err != nil {
+		return fmt.Errorf("error calling %s: %v", NetworkManagerMethodEnable, call.Err)
+	}
+	return nil
+}
+
+// GetWirelessEnabled reports whether the WiFi radio is enabled in software.
+func GetWirelessEnabled(conn *dbus.Conn) (bool, error) {
+	return unix.GetProperty[bool](conn, NetworkManagerInterface, NetworkManagerInterface, NetworkManagerObjectPath, "WirelessEnabled")
+}
+
+// SetWirelessEnabled turns the WiFi radio on or off in software, it can't override a hardware switch.
+func SetWirelessEnabled(conn *dbus.Conn, enabled bool) error {
+	return unix.SetProperty(conn, NetworkManagerInterface, NetworkManagerInterface, NetworkManagerObjectPath, "WirelessEnabled", enabled)
+}
+
+// GetWirelessHardwareEnabled reports whether the WiFi radio is enabled by its hardware (rfkill) switch.
+func GetWirelessHardwareEnabled(conn *dbus.Conn) (bool, error) {
+	return unix.GetProperty[bool](conn, NetworkManagerInterface, NetworkManagerInterface, NetworkManagerObjectPath, "WirelessHardwareEnabled")
+}
+
+// GetDevicesFromConnection returns the paths of the devices the active connection at connPath is using.
+func GetDevicesFromConnection(conn *dbus.Conn, connPath dbus.ObjectPath) ([]dbus.ObjectPath, error) {
 	var devicePaths []dbus.ObjectPath
-	variant, err := (*connObj).GetProperty(connActiveInterface + ".Devices")
+	variant, err := conn.Object(NetworkManagerInterface, connPath).GetProperty(NetworkManagerActiveConnectionInterface + ".Devices")
 	if err != nil {
-		return nil, fmt.Errorf("error during property read %s: %v", connActiveInterface+".Devices", err)
+		return nil, fmt.Errorf("error during property read %s: %v", NetworkManagerActiveConnectionInterface+".Devices", err)
 	}
 	err = variant.Store(&devicePaths)
 	if err != nil {
@@ -156,6 +475,10 @@ func getDevicesFromConnection(connObj *dbus.BusObject) ([]dbus.ObjectPath, error
 	return devicePaths, nil
 }
 
+func getDevicesFromConnection(conn *dbus.Conn, connObj *dbus.BusObject) ([]dbus.ObjectPath, error) {
+	return GetDevicesFromConnection(conn, (*connObj).Path())
+}
+
 func GetPrimaryDevicePath(conn *dbus.Conn) (dbus.ObjectPath, error) {
 	nmObj := getNetworkManagerObject(conn)
 	if nmObj == nil {
@@ -172,6 +495,9 @@ func GetPrimaryDevicePath(conn *dbus.Conn) (dbus.ObjectPath, error) {
 	if err != nil {
 		return "", fmt.Errorf("error storing result of call: %v", err)
 	}
+	if connPath == "/" {
+		return "", ErrNoPrimaryConnection
+	}
 
 	// Get the device from the connection object
 	connObj := conn.Object(NetworkManagerInterface, connPath)
@@ -181,13 +507,13 @@ func GetPrimaryDevicePath(conn *dbus.Conn) (dbus.ObjectPath, error) {
 	//
 
 	// Get the Devices property
-	devicePaths, err := getDevicesFromConnection(&connObj)
+	devicePaths, err := getDevicesFromConnection(conn, &connObj)
 	if err != nil {
 		return "", err
 	}
 
 	if len(devicePaths) > 1 {
-		log.Printf("[Warning] More than one device path for primary connection.")
+		getLogger().Warn("More than one device path for primary connection")
 	} else if len(devicePaths) == 0 {
 		return "", errors.New("no devices are associated with the primary connection")
 	}
@@ -206,6 +532,25 @@ func GetDeviceObjectFromPath(conn *dbus.Conn, devPath dbus.ObjectPath) (*dbus.Bu
 	return &device, nil
 }
 
+// GetDeviceType returns the device's NM_DEVICE_TYPE_* value.
+func GetDeviceType(conn *dbus.Conn, devObj *dbus.BusObject) (uint32, error) {
+	return unix.GetProperty[uint32](conn, NetworkManagerInterface, NetworkManagerDeviceInterface, (*devObj).Path(), "DeviceType")
+}
+
+// GetDeviceManaged reports whether NetworkManager manages the device.
+func GetDeviceManaged(conn *dbus.Conn, devObj *dbus.BusObject) (bool, error) {
+	return unix.GetProperty[bool](conn, NetworkManagerInterface, NetworkManagerDeviceInterface, (*devObj).Path(), "Managed")
+}
+
+/*
+SetDeviceManaged hands the device to NetworkManager or takes it away. Unmanaging a device tears
+down any connection active on it and NetworkManager leaves the interface alone afterwards.
+The setting doesn't survive a NetworkManager restart.
+*/
+func SetDeviceManaged(conn *dbus.Conn, devObj *dbus.BusObject, managed bool) error {
+	return unix.SetProperty(conn, NetworkManagerInterface, NetworkManagerDeviceInterface, (*devObj).Path(), "Managed", managed)
+}
+
 func GetDeviceInterfaceName(conn *dbus.Conn, devObj *dbus.BusObject) (string, error) {
 	variant, err := (*devObj).GetProperty(NetworkManagerInterface + ".Device.Interface")
 	if err != nil {
@@ -219,6 +564,50 @@ func GetDeviceInterfaceName(conn *dbus.Conn, devObj *dbus.BusObject) (string, er
 	return interfaceName, nil
 }
 
+// canonicalHwAddress returns addr in the colon separated upper case form, e.g. "AA:BB:CC:DD:EE:FF".
+func canonicalHwAddress(addr string) (string, error) {
+	mac, err := net.ParseMAC(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid hardware address \"%s\": %v", addr, err)
+	}
+	return strings.ToUpper(mac.String()), nil
+}
+
+// GetDeviceHwAddress returns the device's current MAC address, which may be randomized.
+func GetDeviceHwAddress(conn *dbus.Conn, devObj *dbus.BusObject) (string, error) {
+	addr, err := unix.GetProperty[string](conn, NetworkManagerInterface, NetworkManagerDeviceInterface, (*devObj).Path(), "HwAddress")
+	if err != nil {
+		return "", err
+	}
+	return canonicalHwAddress(addr)
+}
+
+// GetDevicePermanentHwAddress returns the burned-in MAC address of a wireless or wired device.
+func GetDevicePermanentHwAddress(conn *dbus.Conn, devObj *dbus.BusObject) (string, error) {
+	var lastErr error
+	for _, iface := range []string{NetworkManagerWirelessInterface, NetworkManagerWiredInterface} {
+		addr, err := unix.GetProperty[string](conn, NetworkManagerInterface, iface, (*devObj).Path(), "PermHwAddress")
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return canonicalHwAddress(addr)
+	}
+	return "", fmt.Errorf("device has no permanent hardware address: %v", lastErr)
+}
+
+// GetPrimaryConnectionType returns the type of the primary connection, e.g. "802-11-wireless", "802-3-ethernet" or "vpn".
+func GetPrimaryConnectionType(conn *dbus.Conn) (string, error) {
+	connType, err := unix.GetProperty[string](conn, NetworkManagerInterface, NetworkManagerInterface, NetworkManagerObjectPath, "PrimaryConnectionType")
+	if err != nil {
+		return "", err
+	}
+	if connType == "" {
+		return "", ErrNoPrimaryConnection
+	}
+	return connType, nil
+}
+
 func GetPrimaryDeviceObject(conn *dbus.Conn) (*dbus.BusObject, error) {
 	devPath, err := GetPrimaryDevicePath(conn)
 	if err != nil {
@@ -227,12 +616,25 @@ func GetPrimaryDeviceObject(conn *dbus.Conn) (*dbus.BusObject, error) {
 	return GetDeviceObjectFromPath(conn, devPath)
 }
 
+// GetPrimaryInterfaceName returns the interface name (e.g. "wlan0") of the device carrying the primary connection, ErrNoPrimaryConnection if there is none.
+func GetPrimaryInterfaceName(conn *dbus.Conn) (string, error) {
+	devObj, err := GetPrimaryDeviceObject(conn)
+	if err != nil {
+		return "", err
+	}
+	return GetDeviceInterfaceName(conn, devObj)
+}
+
 func GetDevicePathFromInterfaceName(conn *dbus.Conn, interfaceName string) (dbus.ObjectPath, error) {
 	nmObj := getNetworkManagerObject(conn)
 	if nmObj == nil {
 		return "", errors.New("failed to retrieve NetworkManager object")
 	}
 	call := (*nmObj).Call(NetworkManagerMethodGetDeviceFromIFace, 0, interfaceName)
+	var dbusErr dbus.Error
+	if errors.As(call.Err, &dbusErr) && dbusErr.Name == nmUnknownDeviceError {
+		return "", fmt.Errorf("%w: no interface named \"%s\"", ErrDeviceNotFound, interfaceName)
+	}
 	if call.Err != nil {
 		return "", fmt.Errorf("error during call %s: %v", NetworkManagerMethodGetDeviceFromIFace, call.Err)
 	}
@@ -245,50 +647,355 @@ func GetDevicePathFromInterfaceName(conn *dbus.Conn, interfaceName string) (dbus
 	return devicePath, nil
 }
 
+/*
+DeviceInfo identifies a network device known to NetworkManager: its object path, its interface
+name (e.g. "wlan0") and DeviceType, one of the NM_DEVICE_TYPE_* values (see NM_DEVICE_TYPE_MAP for names).
+*/
+type DeviceInfo struct {
+	Path          dbus.ObjectPath
+	InterfaceName string
+	DeviceType    uint32
+}
+
+// GetAllDevices lists every network device known to NetworkManager, including ones it doesn't manage.
+func GetAllDevices(conn *dbus.Conn) ([]DeviceInfo, error) {
+	nmObj := getNetworkManagerObject(conn)
+	call := (*nmObj).Call(NetworkManagerMethodGetAllDevices, 0)
+	if call.Err != nil {
+		return nil, fmt.Errorf("error during call %s: %v", NetworkManagerMethodGetAllDevices, call.Err)
+	}
+	var devPaths []dbus.ObjectPath
+	err := call.Store(&devPaths)
+	if err != nil {
+		return nil, fmt.Errorf("error storing value from call: %v", err)
+	}
+
+	devices := make([]DeviceInfo, 0, len(devPaths))
+	for _, devPath := range devPaths {
+		devObj, err := GetDeviceObjectFromPath(conn, devPath)
+		if err != nil {
+			return nil, err
+		}
+		ifName, err := GetDeviceInterfaceName(conn, devObj)
+		if err != nil {
+			return nil, err
+		}
+		devType, err := GetDeviceType(conn, devObj)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, DeviceInfo{
+			Path:          devPath,
+			InterfaceName: ifName,
+			DeviceType:    devType,
+		})
+	}
+	return devices, nil
+}
+
+/*
+SSID holds the raw bytes as reported by the access point, use it for exact matching.
+Name is the decoded, log-safe form of SSID. Hidden networks come back with an empty SSID and Name.
+There is one SSIDInfo per access point, so several entries may share an SSID.
+
+Strength is in percent (0-100), Frequency in MHz and MaxBitrate in Kb/s.
+HwAddress is the BSSID, Flags holds NM_802_11_AP_FLAGS_* bits and WpaFlags/RsnFlags hold
+NM_802_11_AP_SEC_* bits. Security is derived from those with SecurityType.
+*/
 type SSIDInfo struct {
 	SSID       []byte
+	Name       string
 	ObjectPath dbus.ObjectPath
+	Strength   uint8
+	Frequency  uint32
+	MaxBitrate uint32
+	HwAddress  string
+	Flags      uint32
+	WpaFlags   uint32
+	RsnFlags   uint32
+	Security   string
+}
+
+// SecurityType derives a readable security type ("Open", "WEP", "WPA2-PSK", "WPA3-SAE", ...)
+// from an access point's Flags, WpaFlags and RsnFlags.
+func SecurityType(flags, wpaFlags, rsnFlags uint32) string {
+	switch {
+	case rsnFlags&NM_802_11_AP_SEC_KEY_MGMT_EAP_SUITE_B_192 != 0:
+		return "WPA3-Enterprise"
+	case rsnFlags&NM_802_11_AP_SEC_KEY_MGMT_SAE != 0:
+		return "WPA3-SAE"
+	case rsnFlags&(NM_802_11_AP_SEC_KEY_MGMT_OWE|NM_802_11_AP_SEC_KEY_MGMT_OWE_TM) != 0:
+		return "OWE"
+	case rsnFlags&NM_802_11_AP_SEC_KEY_MGMT_802_1X != 0:
+		return "WPA2-Enterprise"
+	case rsnFlags&NM_802_11_AP_SEC_KEY_MGMT_PSK != 0:
+		return "WPA2-PSK"
+	case wpaFlags&NM_802_11_AP_SEC_KEY_MGMT_802_1X != 0:
+		return "WPA-Enterprise"
+	case wpaFlags&NM_802_11_AP_SEC_KEY_MGMT_PSK != 0:
+		return "WPA-PSK"
+	case flags&NM_802_11_AP_FLAGS_PRIVACY != 0:
+		return "WEP"
+	}
+	return "Open"
+}
+
+// SSIDToName decodes a raw SSID as UTF-8, hex-escaping invalid or non-printable bytes.
+// An SSID made up only of null bytes (as some hidden networks report) decodes to "".
+func SSIDToName(ssid []byte) string {
+	if len(strings.Trim(string(ssid), "\x00")) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	for len(ssid) > 0 {
+		r, size := utf8.DecodeRune(ssid)
+		if (r == utf8.RuneError && size == 1) || !unicode.IsPrint(r) {
+			for _, b := range ssid[:size] {
+				fmt.Fprintf(&sb, "\\x%02x", b)
+			}
+		} else {
+			sb.WriteRune(r)
+		}
+		ssid = ssid[size:]
+	}
+	return sb.String()
 }
 
+const (
+	defaultScanTimeout      = time.Second
+	defaultScanPollInterval = 100 * time.Millisecond
+)
+
+/*
+Timeout bounds how long to wait for the scan to complete, defaulting to 1 second.
+With PollLastScan the device's LastScan property is polled every PollInterval and the
+results are read as soon as it changes, otherwise the full Timeout is waited out.
+With MaxAge set, no scan is requested if the last one finished less than MaxAge ago and
+its results are returned instead, NetworkManager refuses scans requested too often.
+*/
+type ScanOptions struct {
+	Timeout      time.Duration
+	PollLastScan bool
+	PollInterval time.Duration
+	MaxAge       time.Duration
+}
+
+// nmScanNotAllowedError is the D-Bus error NetworkManager replies with to a scan requested too soon after the last one.
+const nmScanNotAllowedError = "org.freedesktop.NetworkManager.Device.NotAllowed"
+
 // GetAvailableSSIDs returns a list of available SSIDs and their D-Bus paths.
 func GetAvailableSSIDs(conn *dbus.Conn, devObj *dbus.BusObject) ([]SSIDInfo, error) {
-	call := (*devObj).Call(NetworkManagerMethodWirelessSSIDScan, 0, map[string]dbus.Variant{})
+	return GetAvailableSSIDsContext(context.Background(), conn, devObj, ScanOptions{})
+}
+
+// GetAvailableSSIDsContext requests a scan and returns the access points found once it completes or
+// opts.Timeout passes. Cancelling ctx aborts the wait and returns ctx.Err().
+func GetAvailableSSIDsContext(ctx context.Context, conn *dbus.Conn, devObj *dbus.BusObject, opts ScanOptions) ([]SSIDInfo, error) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultScanTimeout
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultScanPollInterval
+	}
+
+	err := requireWifiDevice(conn, devObj)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastScan int64
+	if opts.PollLastScan || opts.MaxAge > 0 {
+		lastScan, err = getLastScan(devObj)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if opts.MaxAge > 0 && lastScan >= 0 {
+		// LastScan is in CLOCK_BOOTTIME, which is what /proc/uptime counts
+		now, err := unix.GetUptime()
+		if err != nil {
+			return nil, err
+		}
+		if now-time.Duration(lastScan)*time.Millisecond < opts.MaxAge {
+			return getAccessPoints(conn, devObj)
+		}
+	}
+
+	err = requestScan(ctx, devObj)
+	var dbusErr dbus.Error
+	if errors.As(err, &dbusErr) && dbusErr.Name == nmScanNotAllowedError {
+		getLogger().Warn("Scan not allowed, returning results of the last scan", "err", err)
+		return getAccessPoints(conn, devObj)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = waitScanComplete(ctx, devObj, lastScan, opts)
+	if err != nil {
+		return nil, err
+	}
+	return getAccessPoints(conn, devObj)
+}
+
+func requireWifiDevice(conn *dbus.Conn, devObj *dbus.BusObject) error {
+	devType, err := GetDeviceType(conn, devObj)
+	if err != nil {
+		return err
+	}
+	if devType != NM_DEVICE_TYPE_WIFI {
+		return fmt.Errorf("can't scan with a device of type %s, it must be WiFi", mapName(NM_DEVICE_TYPE_MAP, devType))
+	}
+	return nil
+}
+
+func requestScan(ctx context.Context, devObj *dbus.BusObject) error {
+	call := (*devObj).CallWithContext(ctx, NetworkManagerMethodWirelessSSIDScan, 0, map[string]dbus.Variant{})
 	if call.Err != nil {
-		return nil, fmt.Errorf("error in call to %s: %v", NetworkManagerMethodWirelessSSIDScan, call.Err)
+		return fmt.Errorf("error in call to %s: %w", NetworkManagerMethodWirelessSSIDScan, call.Err)
 	}
-	err := call.Store() // I think this is to make sure execution happens?
+	return nil
+}
+
+/*
+RequestWifiScan asks the WiFi device to scan and returns without waiting for the scan to finish.
+Read the results with GetAccessPoints once the device's LastScan changes.
+*/
+func RequestWifiScan(conn *dbus.Conn, devObj *dbus.BusObject) error {
+	err := requireWifiDevice(conn, devObj)
 	if err != nil {
-		return nil, fmt.Errorf("error storing call: %v", err)
+		return err
+	}
+	return requestScan(context.Background(), devObj)
+}
+
+// GetAccessPoints returns the access points the WiFi device currently knows of, from its last scan, without scanning.
+func GetAccessPoints(conn *dbus.Conn, devObj *dbus.BusObject) ([]SSIDInfo, error) {
+	err := requireWifiDevice(conn, devObj)
+	if err != nil {
+		return nil, err
+	}
+	return getAccessPoints(conn, devObj)
+}
+
+// getLastScan reads the device's LastScan, in CLOCK_BOOTTIME milliseconds (-1 if it never scanned).
+func getLastScan(devObj *dbus.BusObject) (int64, error) {
+	variant, err := (*devObj).GetProperty(NetworkManagerWirelessInterface + ".LastScan")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read LastScan of device: %v", err)
+	}
+	var lastScan int64
+	err = variant.Store(&lastScan)
+	if err != nil {
+		return 0, fmt.Errorf("error storing LastScan: %v", err)
 	}
+	return lastScan, nil
+}
 
-	time.Sleep(time.Second)
+func waitScanComplete(ctx context.Context, devObj *dbus.BusObject, lastScan int64, opts ScanOptions) error {
+	timer := time.NewTimer(opts.Timeout)
+	defer timer.Stop()
+	if !opts.PollLastScan {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		}
+	}
+
+	ticker := time.NewTicker(opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			getLogger().Warn("Scan did not complete in time, reading current results", "timeout", opts.Timeout)
+			return nil
+		case <-ticker.C:
+			current, err := getLastScan(devObj)
+			if err != nil {
+				return err
+			}
+			if current != lastScan {
+				return nil
+			}
+		}
+	}
+}
 
-	call = (*devObj).Call(NetworkManagerMethodGetSSIDs, 0)
+func getAccessPoints(conn *dbus.Conn, devObj *dbus.BusObject) ([]SSIDInfo, error) {
+	call := (*devObj).Call(NetworkManagerMethodGetSSIDs, 0)
 	if call.Err != nil {
 		return nil, fmt.Errorf("error in call to %s: %v", NetworkManagerMethodGetSSIDs, call.Err)
 	}
 	var ssids []dbus.ObjectPath
-	err = call.Store(&ssids)
+	err := call.Store(&ssids)
 	if err != nil {
 		return nil, fmt.Errorf("error storing call: %v", err)
 	}
-	ssidInfos := make([]SSIDInfo, len(ssids))
-	for i, ap := range ssids {
-		var ssid []byte
-		err = conn.Object(NetworkManagerInterface, ap).Call("org.freedesktop.DBus.Properties.Get", 0, "org.freedesktop.NetworkManager.AccessPoint", "Ssid").Store(&ssid)
+	ssidInfos := make([]SSIDInfo, 0, len(ssids))
+	for _, ap := range ssids {
+		info, err := getAccessPointInfo(conn, ap)
 		if err != nil {
-			log.Printf("[Warning] Error getting SSID Info: %v", err)
+			getLogger().Warn("Error getting SSID info", "err", err)
 			continue
 		}
-		ssidInfos[i] = SSIDInfo{
-			SSID:       ssid,
-			ObjectPath: ap,
-		}
+		ssidInfos = append(ssidInfos, info)
 	}
 
 	return ssidInfos, nil
 }
 
+func getAccessPointInfo(conn *dbus.Conn, apPath dbus.ObjectPath) (SSIDInfo, error) {
+	apObj := conn.Object(NetworkManagerInterface, apPath)
+	info := SSIDInfo{ObjectPath: apPath}
+
+	props := []struct {
+		name string
+		dest interface{}
+	}{
+		{"Ssid", &info.SSID},
+		{"Strength", &info.Strength},
+		{"Frequency", &info.Frequency},
+		{"MaxBitrate", &info.MaxBitrate},
+		{"HwAddress", &info.HwAddress},
+		{"Flags", &info.Flags},
+		{"WpaFlags", &info.WpaFlags},
+		{"RsnFlags", &info.RsnFlags},
+	}
+	for _, p := range props {
+		variant, err := apObj.GetProperty(NetworkManagerAccessPointInterface + "." + p.name)
+		if err != nil {
+			return info, fmt.Errorf("failed to read %s of %s: %v", p.name, apPath, err)
+		}
+		err = variant.Store(p.dest)
+		if err != nil {
+			return info, fmt.Errorf("error storing %s of %s: %v", p.name, apPath, err)
+		}
+	}
+	info.Name = SSIDToName(info.SSID)
+	info.Security = SecurityType(info.Flags, info.WpaFlags, info.RsnFlags)
+	return info, nil
+}
+
+// GetActiveSSID returns the name of the network the WiFi device is connected to, or "" if it isn't connected.
+func GetActiveSSID(conn *dbus.Conn, devObj *dbus.BusObject) (string, error) {
+	apPath, err := getActiveAccessPoint(devObj)
+	if err != nil {
+		return "", err
+	}
+	if apPath == "/" {
+		return "", nil
+	}
+	ssid, err := unix.GetProperty[[]byte](conn, NetworkManagerInterface, NetworkManagerAccessPointInterface, apPath, "Ssid")
+	if err != nil {
+		return "", err
+	}
+	return SSIDToName(ssid), nil
+}
+
 func GetDeviceFromInterfaceName(conn *dbus.Conn, interfaceName string) (*dbus.BusObject, error) {
 	devPath, err := GetDevicePathFromInterfaceName(conn, interfaceName)
 	if err != nil {
@@ -298,15 +1005,140 @@ func GetDeviceFromInterfaceName(conn *dbus.Conn, interfaceName string) (*dbus.Bu
 }
 
 func CheckDeviceState(conn *dbus.Conn, devObj *dbus.BusObject) (uint32, error) {
-	var state uint32
-	err := (*devObj).Call(MethodDbusGetProperty, 0, "org.freedesktop.NetworkManager.Device", "State").Store(&state)
-	return state, err
+	return unix.GetProperty[uint32](conn, NetworkManagerInterface, NetworkManagerDeviceInterface, (*devObj).Path(), "State")
+}
+
+// DisconnectDevice disconnects the device at devPath. It returns an error, without calling
+// NetworkManager, if the device is already disconnected.
+func DisconnectDevice(conn *dbus.Conn, devPath dbus.ObjectPath) error {
+	devObj, err := GetDeviceObjectFromPath(conn, devPath)
+	if err != nil {
+		return err
+	}
+	state, err := CheckDeviceState(conn, devObj)
+	if err != nil {
+		return fmt.Errorf("failed to check device state: %w", err)
+	}
+	if state <= NM_DEVICE_STATE_DISCONNECTED {
+		return fmt.Errorf("device %s is already disconnected (state: %s)", devPath, NM_DEVICE_STATE_MAP[state])
+	}
+
+	call := (*devObj).Call(NetworkManagerMethodDeviceDisconnect, 0)
+	if call.Err != nil {
+		return fmt.Errorf("error in call to %s: %v", NetworkManagerMethodDeviceDisconnect, call.Err)
+	}
+	return nil
+}
+
+// DisconnectDeviceAndWait is DisconnectDevice, but blocks until the device reaches
+// NM_DEVICE_STATE_DISCONNECTED or timeout passes.
+func DisconnectDeviceAndWait(conn *dbus.Conn, devPath dbus.ObjectPath, timeout time.Duration) error {
+	sub, err := DeviceStateChangeSubscribe(devPath)
+	if err != nil {
+		return err
+	}
+	defer sub.Join()
+	defer sub.Stop()
+
+	err = DisconnectDevice(conn, devPath)
+	if err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			return fmt.Errorf("timed out waiting for device %s to disconnect", devPath)
+		case change := <-sub.C:
+			if change[0] == NM_DEVICE_STATE_DISCONNECTED {
+				return nil
+			}
+		}
+	}
+}
+
+/*
+Address and Prefix are required, e.g. "192.168.1.20" and 24. Gateway and DNS are optional.
+*/
+type StaticIPv4Config struct {
+	Address string
+	Prefix  uint32
+	Gateway string
+	DNS     []string
+}
+
+/*
+IPv4 left nil keeps the DHCP default.
+Hidden is for networks that don't broadcast their SSID, the SSID isn't required to show up in a scan.
+*/
+type ConnectOptions struct {
+	IPv4   *StaticIPv4Config
+	Hidden bool
+}
+
+func parseIPv4(s string) (net.IP, error) {
+	ip := net.ParseIP(s).To4()
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IPv4 address \"%s\"", s)
+	}
+	return ip, nil
 }
 
-func getConnectionSettings(ssid string, pass string) map[string]map[string]dbus.Variant {
-	return map[string]map[string]dbus.Variant{
+// getIPv4Settings builds the ipv4 settings section, "auto" (DHCP) unless a static config is given.
+func getIPv4Settings(cfg *StaticIPv4Config) (map[string]dbus.Variant, error) {
+	if cfg == nil {
+		return map[string]dbus.Variant{
+			"method": dbus.MakeVariant("auto"),
+		}, nil
+	}
+
+	addr, err := parseIPv4(cfg.Address)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Prefix < 1 || cfg.Prefix > 32 {
+		return nil, fmt.Errorf("invalid IPv4 prefix %d, must be within 1-32", cfg.Prefix)
+	}
+	settings := map[string]dbus.Variant{
+		"method": dbus.MakeVariant("manual"),
+		"address-data": dbus.MakeVariant([]map[string]dbus.Variant{{
+			"address": dbus.MakeVariant(addr.String()),
+			"prefix":  dbus.MakeVariant(cfg.Prefix),
+		}}),
+	}
+	if cfg.Gateway != "" {
+		gw, err := parseIPv4(cfg.Gateway)
+		if err != nil {
+			return nil, fmt.Errorf("bad gateway: %w", err)
+		}
+		settings["gateway"] = dbus.MakeVariant(gw.String())
+	}
+	if len(cfg.DNS) > 0 {
+		// NetworkManager wants these as uint32s holding the address in network byte order
+		dns := make([]uint32, len(cfg.DNS))
+		for i, server := range cfg.DNS {
+			ip, err := parseIPv4(server)
+			if err != nil {
+				return nil, fmt.Errorf("bad DNS server: %w", err)
+			}
+			dns[i] = binary.NativeEndian.Uint32(ip)
+		}
+		settings["dns"] = dbus.MakeVariant(dns)
+	}
+	return settings, nil
+}
+
+func getConnectionSettings(ssid string, pass string, opts ConnectOptions) (map[string]map[string]dbus.Variant, error) {
+	ipv4, err := getIPv4Settings(opts.IPv4)
+	if err != nil {
+		return nil, err
+	}
+	settings := map[string]map[string]dbus.Variant{
 		"802-11-wireless": {
-			"ssid": dbus.MakeVariant([]byte(ssid)), // SSID needs to be a byte slice
+			"ssid":   dbus.MakeVariant([]byte(ssid)), // SSID needs to be a byte slice
+			"hidden": dbus.MakeVariant(opts.Hidden),
 		},
 		"802-11-wireless-security": {
 			"key-mgmt": dbus.MakeVariant("wpa-psk"),
@@ -317,54 +1149,237 @@ func getConnectionSettings(ssid string, pass string) map[string]map[string]dbus.
 			"type":        dbus.MakeVariant("802-11-wireless"),
 			"autoconnect": dbus.MakeVariant(true),
 		},
-		"ipv4": {
-			"method": dbus.MakeVariant("auto"),
-		},
+		"ipv4": ipv4,
 		"ipv6": {
 			"method": dbus.MakeVariant("auto"),
 		},
 	}
+	if pass == "" {
+		// Open network, there must be no security section at all
+		delete(settings, "802-11-wireless-security")
+	}
+	return settings, nil
+}
+
+/*
+ConnectToSSID connects the device to a WPA-PSK network, or to an open network if pass is empty.
+It returns the path of the new active connection and of the saved connection profile it was created from.
+*/
+func ConnectToSSID(ssid string, pass string, conn *dbus.Conn, devPath dbus.ObjectPath) (dbus.ObjectPath, dbus.ObjectPath, error) {
+	return ConnectToSSIDWithOptions(ssid, pass, conn, devPath, ConnectOptions{})
 }
-func ConnectToSSID(ssid string, pass string, conn *dbus.Conn, devPath dbus.ObjectPath) error {
-	// TODO Clean this up
+
+// ConnectToSSIDWithOptions is ConnectToSSID with control over the created profile, e.g. static IPv4 addressing.
+func ConnectToSSIDWithOptions(ssid string, pass string, conn *dbus.Conn, devPath dbus.ObjectPath, opts ConnectOptions) (dbus.ObjectPath, dbus.ObjectPath, error) {
+	connectionSettings, err := getConnectionSettings(ssid, pass, opts)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid connection settings: %w", err)
+	}
+	return addAndActivateWifiConnection(context.Background(), ssid, connectionSettings, conn, devPath, opts.Hidden)
+}
+
+// ConnectToHiddenSSID is ConnectToSSID for a network that doesn't broadcast its SSID.
+func ConnectToHiddenSSID(ssid string, pass string, conn *dbus.Conn, devPath dbus.ObjectPath) (dbus.ObjectPath, dbus.ObjectPath, error) {
+	return ConnectToSSIDWithOptions(ssid, pass, conn, devPath, ConnectOptions{Hidden: true})
+}
+
+/*
+ConnectToSSIDAndWait is ConnectToSSIDWithOptions, but blocks until the device is activated.
+If activation fails due to missing or wrong credentials the error wraps ErrNeedAuth, any other
+failure wraps ErrActivationFailed. ctx bounds the scan and the wait.
+*/
+func ConnectToSSIDAndWait(ctx context.Context, ssid string, pass string, conn *dbus.Conn, devPath dbus.ObjectPath, opts ConnectOptions) (dbus.ObjectPath, dbus.ObjectPath, error) {
+	connectionSettings, err := getConnectionSettings(ssid, pass, opts)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid connection settings: %w", err)
+	}
+
+	// Subscribe before activating so no state change is missed, on conn as the caller keeps using it
+	sub, err := DeviceStateChangeSubscribeOnConn(conn, devPath)
+	if err != nil {
+		return "", "", err
+	}
+	defer sub.Join()
+	defer sub.Stop()
+
+	activeConnPath, connPath, err := addAndActivateWifiConnection(ctx, ssid, connectionSettings, conn, devPath, opts.Hidden)
+	if err != nil {
+		return "", "", err
+	}
+	err = waitDeviceActivated(ctx, sub)
+	return activeConnPath, connPath, err
+}
+
+/*
+ConnectToSSIDWithRetry runs ConnectToSSIDAndWait up to attempts times, waiting backoff between them.
+The connection profile of a failed attempt is deleted before the next one so profiles don't pile
+up. ErrNeedAuth is returned right away, as a wrong password won't fix itself on retry. Every
+attempt and cleanup runs on conn, which is left open.
+*/
+func ConnectToSSIDWithRetry(ctx context.Context, ssid string, pass string, conn *dbus.Conn, devPath dbus.ObjectPath, attempts int, backoff time.Duration) error {
+	if attempts < 1 {
+		return fmt.Errorf("attempts must be at least 1, got %d", attempts)
+	}
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		var connPath dbus.ObjectPath
+		_, connPath, err = ConnectToSSIDAndWait(ctx, ssid, pass, conn, devPath, ConnectOptions{})
+		if err == nil {
+			return nil
+		}
+		if connPath != "" {
+			if delErr := DeleteConnection(conn, connPath); delErr != nil {
+				getLogger().Warn("Failed to delete connection of failed attempt", "connection", connPath, "err", delErr)
+			}
+		}
+		if errors.Is(err, ErrNeedAuth) || ctx.Err() != nil {
+			return err
+		}
+		getLogger().Warn("Attempt to connect failed", "ssid", ssid, "attempt", attempt, "attempts", attempts, "err", err)
+		if attempt == attempts {
+			break
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("stopped retrying: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+	return fmt.Errorf("failed to connect to %s after %d attempts: %w", ssid, attempts, err)
+}
+
+func waitDeviceActivated(ctx context.Context, sub *DeviceStateChangeSubscription) error {
+	sawNeedAuth := false
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting for activation: %w", ctx.Err())
+		case change := <-sub.C:
+			switch change[0] {
+			case NM_DEVICE_STATE_ACTIVATED:
+				return nil
+			case NM_DEVICE_STATE_NEED_AUTH:
+				sawNeedAuth = true
+			case NM_DEVICE_STATE_FAILED:
+				if sawNeedAuth || change[2] == NM_DEVICE_STATE_REASON_NO_SECRETS {
+					return fmt.Errorf("%w (reason: %s)", ErrNeedAuth, mapName(NM_DEVICE_STATE_REASON_MAP, change[2]))
+				}
+				return fmt.Errorf("%w (reason: %s)", ErrActivationFailed, mapName(NM_DEVICE_STATE_REASON_MAP, change[2]))
+			}
+		}
+	}
+}
+
+/*
+EAP defaults to "peap" and Phase2Auth to "mschapv2". CACertPath is optional, without it
+the server certificate is not verified.
+*/
+type EAPConfig struct {
+	EAP        string
+	Phase2Auth string
+	Identity   string
+	Password   string
+	CACertPath string
+}
+
+func getEnterpriseConnectionSettings(ssid string, eap EAPConfig) (map[string]map[string]dbus.Variant, error) {
+	if eap.Identity == "" {
+		return nil, errors.New("EAP identity must not be empty")
+	}
+	if eap.EAP == "" {
+		eap.EAP = "peap"
+	}
+	if eap.Phase2Auth == "" {
+		eap.Phase2Auth = "mschapv2"
+	}
+	settings, err := getConnectionSettings(ssid, "", ConnectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	settings["802-11-wireless-security"] = map[string]dbus.Variant{
+		"key-mgmt": dbus.MakeVariant("wpa-eap"),
+	}
+	settings["802-1x"] = map[string]dbus.Variant{
+		"eap":         dbus.MakeVariant([]string{eap.EAP}),
+		"phase2-auth": dbus.MakeVariant(eap.Phase2Auth),
+		"identity":    dbus.MakeVariant(eap.Identity),
+		"password":    dbus.MakeVariant(eap.Password),
+	}
+	if eap.CACertPath != "" {
+		// Certificates given by path are a NUL terminated "file://" URI
+		settings["802-1x"]["ca-cert"] = dbus.MakeVariant([]byte("file://" + eap.CACertPath + "\x00"))
+	}
+	return settings, nil
+}
+
+/*
+ConnectToEnterpriseSSID connects to a WPA-Enterprise (802.1X) network, e.g. PEAP/MSCHAPv2.
+Note the identity and password are sent to NetworkManager over the system bus in plain text.
+*/
+func ConnectToEnterpriseSSID(ssid string, eap EAPConfig, conn *dbus.Conn, devPath dbus.ObjectPath) (dbus.ObjectPath, dbus.ObjectPath, error) {
+	connectionSettings, err := getEnterpriseConnectionSettings(ssid, eap)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid connection settings: %w", err)
+	}
+	return addAndActivateWifiConnection(context.Background(), ssid, connectionSettings, conn, devPath, false)
+}
+
+// findSSIDPath scans with the device and returns the path of an access point broadcasting ssid.
+func findSSIDPath(ctx context.Context, conn *dbus.Conn, devPath dbus.ObjectPath, ssid string) (dbus.ObjectPath, error) {
 	devObj, err := GetDeviceObjectFromPath(conn, devPath)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	ssids, err := GetAvailableSSIDs(conn, devObj)
+	ssids, err := GetAvailableSSIDsContext(ctx, conn, devObj, ScanOptions{})
 	if err != nil {
-		return fmt.Errorf("failed to scan SSIDS: %w", err)
+		return "", fmt.Errorf("failed to scan SSIDS: %w", err)
 	}
 
-	var ssidPath dbus.ObjectPath
-	ssidMatched := false
 	for _, si := range ssids {
 		if string(si.SSID) == ssid {
-			ssidMatched = true
-			ssidPath = si.ObjectPath
-			break
+			return si.ObjectPath, nil
 		}
 	}
-	if !ssidMatched {
-		return fmt.Errorf("failed to find SSID matching given \"%s\"", ssid)
+	return "", fmt.Errorf("%w: no network matching \"%s\" in scan", ErrSSIDNotFound, ssid)
+}
+
+// addAndActivateWifiConnection checks the SSID is in range, unless it is hidden, then creates and activates the connection.
+func addAndActivateWifiConnection(ctx context.Context, ssid string, connectionSettings map[string]map[string]dbus.Variant, conn *dbus.Conn, devPath dbus.ObjectPath, hidden bool) (dbus.ObjectPath, dbus.ObjectPath, error) {
+	// Hidden networks don't show up in scans, "/" lets NetworkManager pick the access point
+	ssidPath := dbus.ObjectPath("/")
+	if !hidden {
+		var err error
+		ssidPath, err = findSSIDPath(ctx, conn, devPath, ssid)
+		if err != nil {
+			return "", "", err
+		}
 	}
 
-	connectionSettings := getConnectionSettings(ssid, pass)
+	connectionPath, activeConnectionPath, err := addAndActivateConnection(conn, connectionSettings, devPath, ssidPath)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to add and activate connection: %w", err)
+	}
+	return activeConnectionPath, connectionPath, nil
+}
+
+// addAndActivateConnection saves a new connection profile and activates it, returning the paths of the profile and the active connection.
+func addAndActivateConnection(conn *dbus.Conn, settings map[string]map[string]dbus.Variant, devPath dbus.ObjectPath, specificObject dbus.ObjectPath) (dbus.ObjectPath, dbus.ObjectPath, error) {
+	call := conn.Object(NetworkManagerInterface, NetworkManagerObjectPath).Call(NetworkManagerMethodAddAndActivate, 0, settings, devPath, specificObject)
+	if call.Err != nil {
+		return "", "", call.Err
+	}
 	var (
+		connectionPath       dbus.ObjectPath
 		activeConnectionPath dbus.ObjectPath
-		devicePath           dbus.ObjectPath
 	)
-
-	err = conn.Object(NetworkManagerInterface, NetworkManagerObjectPath).Call(
-		"org.freedesktop.NetworkManager.AddAndActivateConnection", 0,
-		connectionSettings, devPath, ssidPath,
-	).Store(&activeConnectionPath, &devicePath)
+	err := call.Store(&connectionPath, &activeConnectionPath)
 	if err != nil {
-		return fmt.Errorf("failed to add and activate connection: %w", err)
+		return "", "", fmt.Errorf("error storing call: %v", err)
 	}
-	fmt.Printf("Connection activated: %s on device: %s\n", activeConnectionPath, devicePath)
-	return nil
+	return connectionPath, activeConnectionPath, nil
 }
 
 type NetworkManagerStateSubscription struct {
@@ -375,30 +1390,52 @@ type NetworkManagerStateSubscription struct {
 
 func goParseNetworkManagerStateSignals(ctx context.Context, wg *sync.WaitGroup, sub *unix.DBusSignalSubscription, outCh chan uint32) {
 	defer wg.Done()
-	defer sub.Conn.Close()
+	defer sub.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case sig := <-sub.C:
+		case sig, ok := <-sub.C:
+			if !ok || sig == nil {
+				return
+			}
 			if len(sig.Body) >= 1 {
 				val, ok := sig.Body[0].(uint32)
 				if ok {
-					outCh <- val
+					select {
+					case outCh <- val:
+					case <-ctx.Done():
+						return
+					}
 				}
 			}
 		}
 	}
 }
 
+var networkManagerStateMatchRule = fmt.Sprintf("type='signal',interface='%s',member='%s',path='%s'", unix.NetworkManagerInterface, unix.NetworkManagerSignalState, unix.NetworkManagerObjectPath)
+
 func GetNetworkManagerStateSubscription() (*NetworkManagerStateSubscription, error) {
-	matchRule := fmt.Sprintf("type='signal',interface='%s',member='%s',path='%s'", unix.NetworkManagerInterface, unix.NetworkManagerSignalState, unix.NetworkManagerObjectPath)
 	sub := &unix.DBusSignalSubscription{}
-	err := sub.MakeDBusSignalSubscription(matchRule, 20)
+	err := sub.MakeDBusSignalSubscription(networkManagerStateMatchRule, 20)
 	if err != nil {
 		return nil, err
 	}
+	return startNetworkManagerStateSubscription(sub), nil
+}
+
+// GetNetworkManagerStateSubscriptionOnConn is like GetNetworkManagerStateSubscription but reuses conn, which is left open when the subscription stops.
+func GetNetworkManagerStateSubscriptionOnConn(conn *dbus.Conn) (*NetworkManagerStateSubscription, error) {
+	sub := &unix.DBusSignalSubscription{}
+	err := sub.MakeDBusSignalSubscriptionOnConn(conn, networkManagerStateMatchRule, 20)
+	if err != nil {
+		return nil, err
+	}
+	return startNetworkManagerStateSubscription(sub), nil
+}
+
+func startNetworkManagerStateSubscription(sub *unix.DBusSignalSubscription) *NetworkManagerStateSubscription {
 	outCh := make(chan uint32, 20)
 	wg := &sync.WaitGroup{}
 	ctx, cancel := context.WithCancel(context.Background())
@@ -407,13 +1444,137 @@ func GetNetworkManagerStateSubscription() (*NetworkManagerStateSubscription, err
 	ret := &NetworkManagerStateSubscription{
 		C:    outCh,
 		Stop: cancel,
-		Join: wg.Done,
+		Join: wg.Wait,
 	}
-	return ret, nil
+	return ret
+}
+
+// changedProperty returns the value of iface's property prop if sig is a PropertiesChanged signal that changed it.
+func changedProperty(sig *dbus.Signal, iface string, prop string) (dbus.Variant, bool) {
+	if sig == nil || sig.Name != DbusPropertiesChangedSignal || len(sig.Body) < 2 {
+		return dbus.Variant{}, false
+	}
+	changedIface, ok := sig.Body[0].(string)
+	if !ok || changedIface != iface {
+		return dbus.Variant{}, false
+	}
+	changed, ok := sig.Body[1].(map[string]dbus.Variant)
+	if !ok {
+		return dbus.Variant{}, false
+	}
+	value, ok := changed[prop]
+	return value, ok
+}
+
+/*
+C <- new connectivity, one of the NM_CONNECTIVITY_* values
+*/
+type NetworkManagerConnectivitySubscription struct {
+	C    chan uint32
+	Stop func()
+	Join func()
+}
+
+func goParseNetworkManagerConnectivitySignals(ctx context.Context, wg *sync.WaitGroup, sub *unix.DBusSignalSubscription, outCh chan uint32, o subscriptionOptions) {
+	defer wg.Done()
+	defer sub.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig, ok := <-sub.C:
+			if !ok || sig == nil {
+				return
+			}
+			variant, ok := changedProperty(sig, NetworkManagerInterface, "Connectivity")
+			if !ok {
+				continue
+			}
+			val, ok := variant.Value().(uint32)
+			if !ok {
+				continue
+			}
+			if _, known := NM_CONNECTIVITY_MAP[val]; !known {
+				o.log().Warn("Unknown connectivity value", "value", val)
+				continue
+			}
+			select {
+			case outCh <- val:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+var networkManagerConnectivityMatchRule = fmt.Sprintf("type='signal',interface='%s',member='%s',path='%s',arg0='%s'", DbusPropertiesInterface, DbusPropertiesChangedMember, NetworkManagerObjectPath, NetworkManagerInterface)
+
+func GetNetworkManagerConnectivitySubscription(opts ...SubscriptionOption) (*NetworkManagerConnectivitySubscription, error) {
+	sub := &unix.DBusSignalSubscription{}
+	err := sub.MakeDBusSignalSubscription(networkManagerConnectivityMatchRule, 20)
+	if err != nil {
+		return nil, err
+	}
+	return startNetworkManagerConnectivitySubscription(sub, opts), nil
+}
+
+// GetNetworkManagerConnectivitySubscriptionOnConn is like GetNetworkManagerConnectivitySubscription but reuses conn, which is left open when the subscription stops.
+func GetNetworkManagerConnectivitySubscriptionOnConn(conn *dbus.Conn, opts ...SubscriptionOption) (*NetworkManagerConnectivitySubscription, error) {
+	sub := &unix.DBusSignalSubscription{}
+	err := sub.MakeDBusSignalSubscriptionOnConn(conn, networkManagerConnectivityMatchRule, 20)
+	if err != nil {
+		return nil, err
+	}
+	return startNetworkManagerConnectivitySubscription(sub, opts), nil
+}
+
+func startNetworkManagerConnectivitySubscription(sub *unix.DBusSignalSubscription, opts []SubscriptionOption) *NetworkManagerConnectivitySubscription {
+	outCh := make(chan uint32, 20)
+	wg := &sync.WaitGroup{}
+	ctx, cancel := context.WithCancel(context.Background())
+	wg.Add(1)
+	go goParseNetworkManagerConnectivitySignals(ctx, wg, sub, outCh, newSubscriptionOptions(opts))
+	ret := &NetworkManagerConnectivitySubscription{
+		C:    outCh,
+		Stop: cancel,
+		Join: wg.Wait,
+	}
+	return ret
+}
+
+/*
+WaitForConnectivity blocks until NetworkManager's connectivity is at least target (one of the
+NM_CONNECTIVITY_* values, e.g. NM_CONNECTIVITY_FULL) or ctx is done.
+*/
+func WaitForConnectivity(ctx context.Context, conn *dbus.Conn, target uint32) error {
+	// Subscribe before reading the current value so no change is missed in between
+	sub, err := GetNetworkManagerConnectivitySubscriptionOnConn(conn)
+	if err != nil {
+		return err
+	}
+	defer sub.Join()
+	defer sub.Stop()
+
+	current, err := unix.GetProperty[uint32](conn, NetworkManagerInterface, NetworkManagerInterface, NetworkManagerObjectPath, "Connectivity")
+	if err != nil {
+		return err
+	}
+	for current < target {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting for connectivity %s (at %s): %w", mapName(NM_CONNECTIVITY_MAP, target), mapName(NM_CONNECTIVITY_MAP, current), ctx.Err())
+		case current = <-sub.C:
+		}
+	}
+	return nil
 }
 
 /*
 C <- (new state, old state, reason)
+
+DeviceStateChangeSubscribe gives the subscription its own connection to the system bus, closed
+by Stop.
 */
 type DeviceStateChangeSubscription struct {
 	C    chan [3]uint32
@@ -422,66 +1583,146 @@ type DeviceStateChangeSubscription struct {
 }
 
 func deviceStateChangeSubscribe(devPath dbus.ObjectPath) (*dbus.Conn, chan *dbus.Signal, error) {
-	conn, err := dbus.SystemBus()
+	// A private connection, as the subscription closes it when stopped
+	conn, err := dbus.ConnectSystemBus()
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to connect to System Bus: %w", err)
 	}
+	c, err := deviceStateChangeSubscribeOnConn(conn, devPath)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	return conn, c, nil
+}
 
-	matchRule := dbus.WithMatchObjectPath(devPath)
-	conn.AddMatchSignal(matchRule)
+func deviceStateChangeSubscribeOnConn(conn *dbus.Conn, devPath dbus.ObjectPath) (chan *dbus.Signal, error) {
+	err := conn.AddMatchSignal(dbus.WithMatchObjectPath(devPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to add match for device %s: %v", devPath, err)
+	}
 	c := make(chan *dbus.Signal, 20)
 	conn.Signal(c)
-
-	return conn, c, nil
+	return c, nil
 }
 
-func goParseDeviceStateChangeSignals(ctx context.Context, wg *sync.WaitGroup, conn *dbus.Conn, devPath dbus.ObjectPath, sigCh chan *dbus.Signal, outCh chan [3]uint32) {
+func goParseDeviceStateChangeSignals(ctx context.Context, wg *sync.WaitGroup, conn *dbus.Conn, ownsConn bool, devPath dbus.ObjectPath, sigCh chan *dbus.Signal, outCh chan [3]uint32, o subscriptionOptions) {
 	defer wg.Done()
-	defer conn.Close()
+	defer func() {
+		if ownsConn {
+			conn.Close()
+			return
+		}
+		conn.RemoveSignal(sigCh)
+		conn.RemoveMatchSignal(dbus.WithMatchObjectPath(devPath))
+	}()
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case sig := <-sigCh:
-			if (sig.Path == devPath) && (sig.Name == DeviceStateChangedSignal) && (len(sig.Body) >= 3) {
+		case sig, ok := <-sigCh:
+			if !ok || sig == nil {
+				return
+			}
+			if (sig.Path == devPath) && (sig.Name == DeviceStateChangedSignal) {
 				var values [3]uint32
-				v, ok := sig.Body[0].(uint32)
-				if !ok {
-					continue
-				}
-				values[0] = v
-				v, ok = sig.Body[1].(uint32)
-				if !ok {
+				err := unix.ParseSignalBody(sig, &values[0], &values[1], &values[2])
+				if err != nil {
+					o.log().Warn("Malformed device state signal", "err", err)
 					continue
 				}
-				values[1] = v
-				v, ok = sig.Body[2].(uint32)
-				if !ok {
-					continue
+				select {
+				case outCh <- values:
+				case <-ctx.Done():
+					return
 				}
-				values[2] = v
-				outCh <- values
 			}
 		}
 	}
 
 }
 
-func DeviceStateChangeSubscribe(devPath dbus.ObjectPath) (*DeviceStateChangeSubscription, error) {
-	conn, sigCh, err := deviceStateChangeSubscribe(devPath)
-	if err != nil {
-		return nil, err
-	}
+func startDeviceStateChangeSubscription(conn *dbus.Conn, ownsConn bool, devPath dbus.ObjectPath, sigCh chan *dbus.Signal, opts []SubscriptionOption) *DeviceStateChangeSubscription {
 	outCh := make(chan [3]uint32, 20)
 	ctx, cancel := context.WithCancel(context.Background())
 	wg := &sync.WaitGroup{}
 	wg.Add(1)
-	go goParseDeviceStateChangeSignals(ctx, wg, conn, devPath, sigCh, outCh)
-	ret := &DeviceStateChangeSubscription{
+	go goParseDeviceStateChangeSignals(ctx, wg, conn, ownsConn, devPath, sigCh, outCh, newSubscriptionOptions(opts))
+	return &DeviceStateChangeSubscription{
 		C:    outCh,
 		Stop: cancel,
 		Join: wg.Wait,
 	}
-	return ret, nil
+}
+
+func DeviceStateChangeSubscribe(devPath dbus.ObjectPath, opts ...SubscriptionOption) (*DeviceStateChangeSubscription, error) {
+	conn, sigCh, err := deviceStateChangeSubscribe(devPath)
+	if err != nil {
+		return nil, err
+	}
+	return startDeviceStateChangeSubscription(conn, true, devPath, sigCh, opts), nil
+}
+
+// DeviceStateChangeSubscribeOnConn is like DeviceStateChangeSubscribe but listens on conn, which Stop leaves open.
+func DeviceStateChangeSubscribeOnConn(conn *dbus.Conn, devPath dbus.ObjectPath, opts ...SubscriptionOption) (*DeviceStateChangeSubscription, error) {
+	sigCh, err := deviceStateChangeSubscribeOnConn(conn, devPath)
+	if err != nil {
+		return nil, err
+	}
+	return startDeviceStateChangeSubscription(conn, false, devPath, sigCh, opts), nil
+}
+
+// deviceStateReason is the (state, reason) struct of a device's StateReason property
+type deviceStateReason struct {
+	State  uint32
+	Reason uint32
+}
+
+/*
+WaitForDeviceState blocks until the device at devPath reaches target (one of the NM_DEVICE_STATE_*
+values) or ctx is done. If the device fails first, the error wraps ErrActivationFailed and reason
+is the NM_DEVICE_STATE_REASON_* it failed with.
+*/
+func WaitForDeviceState(ctx context.Context, devPath dbus.ObjectPath, target uint32) (reason uint32, err error) {
+	conn, sigCh, err := deviceStateChangeSubscribe(devPath)
+	if err != nil {
+		return 0, err
+	}
+	return waitForDeviceState(ctx, conn, startDeviceStateChangeSubscription(conn, true, devPath, sigCh, nil), devPath, target)
+}
+
+// WaitForDeviceStateOnConn is like WaitForDeviceState but listens on conn, which it leaves open.
+func WaitForDeviceStateOnConn(ctx context.Context, conn *dbus.Conn, devPath dbus.ObjectPath, target uint32) (reason uint32, err error) {
+	sub, err := DeviceStateChangeSubscribeOnConn(conn, devPath)
+	if err != nil {
+		return 0, err
+	}
+	return waitForDeviceState(ctx, conn, sub, devPath, target)
+}
+
+// waitForDeviceState reads the device's current state on conn, then follows sub until it reaches target.
+func waitForDeviceState(ctx context.Context, conn *dbus.Conn, sub *DeviceStateChangeSubscription, devPath dbus.ObjectPath, target uint32) (uint32, error) {
+	defer sub.Join()
+	defer sub.Stop()
+
+	// Subscribed before reading the current state so no change is missed in between
+	current, err := unix.GetProperty[deviceStateReason](conn, NetworkManagerInterface, NetworkManagerDeviceInterface, devPath, "StateReason")
+	if err != nil {
+		return 0, err
+	}
+	state, reason := current.State, current.Reason
+
+	for state != target {
+		if state == NM_DEVICE_STATE_FAILED {
+			return reason, fmt.Errorf("%w (reason: %s)", ErrActivationFailed, mapName(NM_DEVICE_STATE_REASON_MAP, reason))
+		}
+		select {
+		case <-ctx.Done():
+			return 0, fmt.Errorf("stopped waiting for device state %s (at %s): %w", mapName(NM_DEVICE_STATE_MAP, target), mapName(NM_DEVICE_STATE_MAP, state), ctx.Err())
+		case change := <-sub.C:
+			state, reason = change[0], change[2]
+		}
+	}
+	return reason, nil
 }
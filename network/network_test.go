@@ -0,0 +1,26 @@
+package network
+
+import "testing"
+
+func TestSSIDToName(t *testing.T) {
+	tests := []struct {
+		name string
+		ssid []byte
+		want string
+	}{
+		{"empty", nil, ""},
+		{"all zero", []byte{0, 0, 0, 0}, ""},
+		{"ascii", []byte("HomeNet"), "HomeNet"},
+		{"utf-8", []byte("Café ☕"), "Café ☕"},
+		{"invalid utf-8", []byte{'a', 0xff, 'b'}, `a\xffb`},
+		{"truncated utf-8", []byte{'x', 0xe2, 0x98}, `x\xe2\x98`},
+		{"non-printable", []byte("a\tb\x00"), `a\x09b\x00`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SSIDToName(tt.ssid); got != tt.want {
+				t.Errorf("SSIDToName(%q) = %q, want %q", tt.ssid, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package network
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// validateCountryCode checks code is an ISO 3166-1 alpha-2 code, or "00" for the world domain, and returns it upper-cased.
+func validateCountryCode(code string) (string, error) {
+	code = strings.ToUpper(code)
+	if code == "00" {
+		return code, nil
+	}
+	if len(code) != 2 || code[0] < 'A' || code[0] > 'Z' || code[1] < 'A' || code[1] > 'Z' {
+		return "", fmt.Errorf("invalid country code %q, must be two letters", code)
+	}
+	return code, nil
+}
+
+/*
+SetWifiCountryCode sets the wireless regulatory domain, e.g. "DE" or "US", which decides the allowed
+channels and transmit power. NetworkManager has no D-Bus API for this, so it runs `iw reg set`;
+iw must be installed and the caller needs CAP_NET_ADMIN. The kernel doesn't persist the setting,
+it has to be applied again after a reboot.
+*/
+func SetWifiCountryCode(code string) error {
+	code, err := validateCountryCode(code)
+	if err != nil {
+		return err
+	}
+	out, err := exec.Command("iw", "reg", "set", code).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to run iw reg set: %v (%s)", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// GetWifiCountryCode returns the global wireless regulatory domain as reported by `iw reg get`, "00" being the world domain.
+func GetWifiCountryCode() (string, error) {
+	out, err := exec.Command("iw", "reg", "get").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run iw reg get: %v", err)
+	}
+	// The first "country XX: DFS-..." line is the global domain, per-phy ones follow it
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "country ") {
+			continue
+		}
+		code, _, ok := strings.Cut(strings.TrimPrefix(line, "country "), ":")
+		if !ok {
+			continue
+		}
+		return code, nil
+	}
+	return "", fmt.Errorf("no country in iw reg get output")
+}
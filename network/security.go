@@ -0,0 +1,74 @@
+package network
+
+/*
+SecurityFlags is a decoded NM_802_11_AP_SEC_* bitfield, as found in an access point's WpaFlags or
+RsnFlags.
+
+NetworkManager doesn't report whether management frame protection (802.11w) is required, but SAE
+and OWE mandate it: an AP offering SAE without PSK requires MFP, one offering both runs WPA3
+transition mode and only needs it from WPA3 clients.
+*/
+type SecurityFlags struct {
+	PairwiseWEP40  bool
+	PairwiseWEP104 bool
+	PairwiseTKIP   bool
+	PairwiseCCMP   bool
+	GroupWEP40     bool
+	GroupWEP104    bool
+	GroupTKIP      bool
+	GroupCCMP      bool
+
+	KeyMgmtPSK           bool
+	KeyMgmt8021X         bool
+	KeyMgmtSAE           bool
+	KeyMgmtOWE           bool
+	KeyMgmtOWETransition bool
+	KeyMgmtSuiteB192     bool
+}
+
+// DecodeSecurityFlags splits a WpaFlags or RsnFlags value into its NM_802_11_AP_SEC_* bits.
+func DecodeSecurityFlags(flags uint32) SecurityFlags {
+	return SecurityFlags{
+		PairwiseWEP40:  flags&NM_802_11_AP_SEC_PAIR_WEP40 != 0,
+		PairwiseWEP104: flags&NM_802_11_AP_SEC_PAIR_WEP104 != 0,
+		PairwiseTKIP:   flags&NM_802_11_AP_SEC_PAIR_TKIP != 0,
+		PairwiseCCMP:   flags&NM_802_11_AP_SEC_PAIR_CCMP != 0,
+		GroupWEP40:     flags&NM_802_11_AP_SEC_GROUP_WEP40 != 0,
+		GroupWEP104:    flags&NM_802_11_AP_SEC_GROUP_WEP104 != 0,
+		GroupTKIP:      flags&NM_802_11_AP_SEC_GROUP_TKIP != 0,
+		GroupCCMP:      flags&NM_802_11_AP_SEC_GROUP_CCMP != 0,
+
+		KeyMgmtPSK:           flags&NM_802_11_AP_SEC_KEY_MGMT_PSK != 0,
+		KeyMgmt8021X:         flags&NM_802_11_AP_SEC_KEY_MGMT_802_1X != 0,
+		KeyMgmtSAE:           flags&NM_802_11_AP_SEC_KEY_MGMT_SAE != 0,
+		KeyMgmtOWE:           flags&NM_802_11_AP_SEC_KEY_MGMT_OWE != 0,
+		KeyMgmtOWETransition: flags&NM_802_11_AP_SEC_KEY_MGMT_OWE_TM != 0,
+		KeyMgmtSuiteB192:     flags&NM_802_11_AP_SEC_KEY_MGMT_EAP_SUITE_B_192 != 0,
+	}
+}
+
+// MFPRequired reports whether clients must use management frame protection, see SecurityFlags.
+func (f SecurityFlags) MFPRequired() bool {
+	return (f.KeyMgmtSAE && !f.KeyMgmtPSK) || f.KeyMgmtOWE || f.KeyMgmtSuiteB192
+}
+
+// WPA3Transition reports whether the AP accepts both WPA2-PSK and WPA3-SAE clients.
+func (f SecurityFlags) WPA3Transition() bool {
+	return f.KeyMgmtSAE && f.KeyMgmtPSK
+}
+
+// WeakPairwiseOnly reports whether the AP advertises pairwise ciphers but none stronger than TKIP.
+func (f SecurityFlags) WeakPairwiseOnly() bool {
+	weak := f.PairwiseWEP40 || f.PairwiseWEP104 || f.PairwiseTKIP
+	return weak && !f.PairwiseCCMP
+}
+
+// WpaSecurity decodes the AP's WPA (version 1) flags.
+func (s SSIDInfo) WpaSecurity() SecurityFlags {
+	return DecodeSecurityFlags(s.WpaFlags)
+}
+
+// RsnSecurity decodes the AP's RSN (WPA2/WPA3) flags.
+func (s SSIDInfo) RsnSecurity() SecurityFlags {
+	return DecodeSecurityFlags(s.RsnFlags)
+}
@@ -0,0 +1,265 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Potsdam-Sensors/GoLinuxToolkit/unix"
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	NetworkManagerSettingsInterface           = "org.freedesktop.NetworkManager.Settings"
+	NetworkManagerSettingsObjectPath          = dbus.ObjectPath("/org/freedesktop/NetworkManager/Settings")
+	NetworkManagerSettingsConnectionInterface = "org.freedesktop.NetworkManager.Settings.Connection"
+
+	NetworkManagerMethodListConnections = NetworkManagerSettingsInterface + ".ListConnections"
+	NetworkManagerMethodGetConnByUUID   = NetworkManagerSettingsInterface + ".GetConnectionByUuid"
+	NetworkManagerMethodGetSettings     = NetworkManagerSettingsConnectionInterface + ".GetSettings"
+	NetworkManagerMethodDelete          = NetworkManagerSettingsConnectionInterface + ".Delete"
+	NetworkManagerMethodUpdate          = NetworkManagerSettingsConnectionInterface + ".Update"
+
+	NetworkManagerMethodActivateConnection   = "org.freedesktop.NetworkManager.ActivateConnection"
+	NetworkManagerMethodDeactivateConnection = "org.freedesktop.NetworkManager.DeactivateConnection"
+	NetworkManagerMethodAddAndActivate       = "org.freedesktop.NetworkManager.AddAndActivateConnection"
+	NetworkManagerActiveConnectionInterface  = "org.freedesktop.NetworkManager.Connection.Active"
+)
+
+const (
+	NM_SETTING_WIRELESS_POWERSAVE_DEFAULT = 0 // use the global default value
+	NM_SETTING_WIRELESS_POWERSAVE_IGNORE  = 1 // don't touch existing setting
+	NM_SETTING_WIRELESS_POWERSAVE_DISABLE = 2 // disable powersave
+	NM_SETTING_WIRELESS_POWERSAVE_ENABLE  = 3 // enable powersave
+)
+
+const (
+	NM_METERED_UNKNOWN   = 0 // the metered status is unknown
+	NM_METERED_YES       = 1 // metered, the value was explicitly configured
+	NM_METERED_NO        = 2 // not metered, the value was explicitly configured
+	NM_METERED_GUESS_YES = 3 // metered, the value was guessed
+	NM_METERED_GUESS_NO  = 4 // not metered, the value was guessed
+)
+
+var NM_METERED_MAP = map[uint32]string{
+	NM_METERED_UNKNOWN:   "Unknown",
+	NM_METERED_YES:       "Yes",
+	NM_METERED_NO:        "No",
+	NM_METERED_GUESS_YES: "Guess yes",
+	NM_METERED_GUESS_NO:  "Guess no",
+}
+
+// listConnectionPaths returns the paths of all saved connection profiles.
+func listConnectionPaths(conn *dbus.Conn) ([]dbus.ObjectPath, error) {
+	var paths []dbus.ObjectPath
+	err := unix.CallMethod(conn, NetworkManagerInterface, NetworkManagerSettingsObjectPath, NetworkManagerMethodListConnections, &paths)
+	if err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// getSettings returns the settings of the saved connection, secrets are left out.
+func getSettings(conn *dbus.Conn, connPath dbus.ObjectPath) (map[string]map[string]dbus.Variant, error) {
+	var settings map[string]map[string]dbus.Variant
+	err := unix.CallMethod(conn, NetworkManagerInterface, connPath, NetworkManagerMethodGetSettings, &settings)
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+/*
+updateSettings loads the saved connection's settings, lets modify change them and writes all of them back.
+*/
+func updateSettings(conn *dbus.Conn, connPath dbus.ObjectPath, modify func(settings map[string]map[string]dbus.Variant)) error {
+	settings, err := getSettings(conn, connPath)
+	if err != nil {
+		return err
+	}
+	// NetworkManager ignores address-data/route-data when the deprecated forms are sent too
+	for _, section := range []string{"ipv4", "ipv6"} {
+		delete(settings[section], "addresses")
+		delete(settings[section], "routes")
+	}
+	modify(settings)
+
+	return unix.CallMethod(conn, NetworkManagerInterface, connPath, NetworkManagerMethodUpdate, nil, settings)
+}
+
+// SetConnectionAutoconnect sets whether NetworkManager activates the saved connection on its own, leaving its other settings as they are.
+func SetConnectionAutoconnect(conn *dbus.Conn, connPath dbus.ObjectPath, enabled bool) error {
+	return updateSettings(conn, connPath, func(settings map[string]map[string]dbus.Variant) {
+		if settings["connection"] == nil {
+			settings["connection"] = map[string]dbus.Variant{}
+		}
+		settings["connection"]["autoconnect"] = dbus.MakeVariant(enabled)
+	})
+}
+
+/*
+SSID is only set for WiFi connections ("802-11-wireless" Type), decoded like SSIDInfo.Name.
+*/
+type SavedConnection struct {
+	Path dbus.ObjectPath
+	ID   string
+	UUID string
+	Type string
+	SSID string
+}
+
+// ListSavedConnections returns every connection profile stored by NetworkManager.
+func ListSavedConnections(conn *dbus.Conn) ([]SavedConnection, error) {
+	paths, err := listConnectionPaths(conn)
+	if err != nil {
+		return nil, err
+	}
+	saved := make([]SavedConnection, 0, len(paths))
+	for _, connPath := range paths {
+		settings, err := getSettings(conn, connPath)
+		if err != nil {
+			return nil, err
+		}
+		sc := SavedConnection{Path: connPath}
+		sc.ID, _ = settings["connection"]["id"].Value().(string)
+		sc.UUID, _ = settings["connection"]["uuid"].Value().(string)
+		sc.Type, _ = settings["connection"]["type"].Value().(string)
+		if ssid, ok := settings["802-11-wireless"]["ssid"].Value().([]byte); ok {
+			sc.SSID = SSIDToName(ssid)
+		}
+		saved = append(saved, sc)
+	}
+	return saved, nil
+}
+
+// DeleteConnection removes the saved connection profile at connPath, deactivating it if it is active.
+func DeleteConnection(conn *dbus.Conn, connPath dbus.ObjectPath) error {
+	return unix.CallMethod(conn, NetworkManagerInterface, connPath, NetworkManagerMethodDelete, nil)
+}
+
+/*
+DeleteConnectionBySSID deletes every saved connection whose id is ssid, as ConnectToSSID names its
+profiles after the SSID. It returns an error if there is no such connection.
+*/
+func DeleteConnectionBySSID(conn *dbus.Conn, ssid string) error {
+	saved, err := ListSavedConnections(conn)
+	if err != nil {
+		return err
+	}
+	deleted := 0
+	for _, sc := range saved {
+		if sc.ID != ssid {
+			continue
+		}
+		err = DeleteConnection(conn, sc.Path)
+		if err != nil {
+			return err
+		}
+		deleted++
+	}
+	if deleted == 0 {
+		return fmt.Errorf("no saved connection with id \"%s\"", ssid)
+	}
+	return nil
+}
+
+/*
+ActivateConnection activates the saved connection at connPath on the device at devPath, without
+creating a new profile like ConnectToSSID does. It returns the path of the new active connection.
+*/
+func ActivateConnection(conn *dbus.Conn, connPath dbus.ObjectPath, devPath dbus.ObjectPath) (dbus.ObjectPath, error) {
+	var activeConnPath dbus.ObjectPath
+	err := unix.CallMethod(conn, NetworkManagerInterface, NetworkManagerObjectPath, NetworkManagerMethodActivateConnection, &activeConnPath, connPath, devPath, dbus.ObjectPath("/"))
+	if err != nil {
+		return "", err
+	}
+	return activeConnPath, nil
+}
+
+// GetConnectionPathByUUID returns the path of the saved connection with the given UUID.
+func GetConnectionPathByUUID(conn *dbus.Conn, uuid string) (dbus.ObjectPath, error) {
+	var connPath dbus.ObjectPath
+	err := unix.CallMethod(conn, NetworkManagerInterface, NetworkManagerSettingsObjectPath, NetworkManagerMethodGetConnByUUID, &connPath, uuid)
+	if err != nil {
+		return "", err
+	}
+	return connPath, nil
+}
+
+// ActivateConnectionByUUID is ActivateConnection for the saved connection with the given UUID.
+func ActivateConnectionByUUID(conn *dbus.Conn, uuid string, devPath dbus.ObjectPath) (dbus.ObjectPath, error) {
+	connPath, err := GetConnectionPathByUUID(conn, uuid)
+	if err != nil {
+		return "", err
+	}
+	return ActivateConnection(conn, connPath, devPath)
+}
+
+// getDeviceConnectionPath returns the saved connection the device's active connection was created from.
+func getDeviceConnectionPath(conn *dbus.Conn, devObj *dbus.BusObject) (dbus.ObjectPath, error) {
+	acPath, err := unix.GetProperty[dbus.ObjectPath](conn, NetworkManagerInterface, NetworkManagerDeviceInterface, (*devObj).Path(), "ActiveConnection")
+	if err != nil {
+		return "", err
+	}
+	if acPath == "/" {
+		return "", errors.New("device has no active connection")
+	}
+	return unix.GetProperty[dbus.ObjectPath](conn, NetworkManagerInterface, NetworkManagerActiveConnectionInterface, acPath, "Connection")
+}
+
+// GetWifiPowerSave returns the 802-11-wireless.powersave mode (NM_SETTING_WIRELESS_POWERSAVE_*) of the device's active connection.
+func GetWifiPowerSave(conn *dbus.Conn, devObj *dbus.BusObject) (uint32, error) {
+	connPath, err := getDeviceConnectionPath(conn, devObj)
+	if err != nil {
+		return 0, err
+	}
+	settings, err := getSettings(conn, connPath)
+	if err != nil {
+		return 0, err
+	}
+	// Not being set at all means the default
+	mode, _ := settings["802-11-wireless"]["powersave"].Value().(uint32)
+	return mode, nil
+}
+
+/*
+SetWifiPowerSave sets the 802-11-wireless.powersave mode of the device's active connection:
+0 (default), 1 (ignore), 2 (disable) or 3 (enable). It is saved to the connection profile and
+applied by NetworkManager the next time the connection is activated.
+*/
+func SetWifiPowerSave(conn *dbus.Conn, devObj *dbus.BusObject, mode uint32) error {
+	if mode > NM_SETTING_WIRELESS_POWERSAVE_ENABLE {
+		return fmt.Errorf("invalid powersave mode %d", mode)
+	}
+	connPath, err := getDeviceConnectionPath(conn, devObj)
+	if err != nil {
+		return err
+	}
+	return updateSettings(conn, connPath, func(settings map[string]map[string]dbus.Variant) {
+		if settings["802-11-wireless"] == nil {
+			settings["802-11-wireless"] = map[string]dbus.Variant{}
+		}
+		settings["802-11-wireless"]["powersave"] = dbus.MakeVariant(mode)
+	})
+}
+
+/*
+SetConnectionMetered marks the saved connection as metered or not, so NetworkManager and the
+applications asking it can hold back on data. It applies the next time the connection is activated.
+*/
+func SetConnectionMetered(conn *dbus.Conn, connPath dbus.ObjectPath, metered bool) error {
+	value := int32(NM_METERED_NO)
+	if metered {
+		value = NM_METERED_YES
+	}
+	return updateSettings(conn, connPath, func(settings map[string]map[string]dbus.Variant) {
+		if settings["connection"] == nil {
+			settings["connection"] = map[string]dbus.Variant{}
+		}
+		settings["connection"]["metered"] = dbus.MakeVariant(value)
+	})
+}
+
+// GetMetered returns whether the device's current connection is metered, one of the NM_METERED_* values.
+func GetMetered(conn *dbus.Conn, devObj *dbus.BusObject) (uint32, error) {
+	return unix.GetProperty[uint32](conn, NetworkManagerInterface, NetworkManagerDeviceInterface, (*devObj).Path(), "Metered")
+}
@@ -0,0 +1,54 @@
+package network
+
+import (
+	"sort"
+	"strings"
+)
+
+// Orders for FilterOptions.SortBy
+const (
+	SortNone     = ""
+	SortStrength = "strength" // strongest first
+	SortName     = "name"     // alphabetically, ignoring case
+)
+
+/*
+FilterOptions selects and orders scan results for FilterAndSortSSIDs. The zero value keeps every
+access point in its original order. Band is one of Band2_4GHz, Band5GHz or Band6GHz, "" for all.
+*/
+type FilterOptions struct {
+	DropHidden  bool
+	MinStrength uint8
+	Band        string
+	SortBy      string
+}
+
+// FilterAndSortSSIDs returns the access points of infos that pass opts, sorted as it says. infos itself isn't modified.
+func FilterAndSortSSIDs(infos []SSIDInfo, opts FilterOptions) []SSIDInfo {
+	filtered := make([]SSIDInfo, 0, len(infos))
+	for _, info := range infos {
+		// Hidden networks broadcast an empty or all-zero SSID, which gives an empty name
+		if opts.DropHidden && info.Name == "" {
+			continue
+		}
+		if info.Strength < opts.MinStrength {
+			continue
+		}
+		if opts.Band != "" && FreqToBand(info.Frequency) != opts.Band {
+			continue
+		}
+		filtered = append(filtered, info)
+	}
+
+	switch opts.SortBy {
+	case SortStrength:
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return filtered[i].Strength > filtered[j].Strength
+		})
+	case SortName:
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return strings.ToLower(filtered[i].Name) < strings.ToLower(filtered[j].Name)
+		})
+	}
+	return filtered
+}
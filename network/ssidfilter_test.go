@@ -0,0 +1,61 @@
+package network
+
+import (
+	"reflect"
+	"testing"
+)
+
+func ssidNames(infos []SSIDInfo) []string {
+	out := make([]string, len(infos))
+	for i, info := range infos {
+		out[i] = info.Name
+	}
+	return out
+}
+
+func TestFilterAndSortSSIDs(t *testing.T) {
+	infos := []SSIDInfo{
+		{Name: "bravo", Strength: 40, Frequency: 2437},
+		{Name: "", Strength: 90, Frequency: 5180},
+		{Name: "Alpha", Strength: 70, Frequency: 5180},
+		{Name: "charlie", Strength: 70, Frequency: 5955},
+		{Name: "delta", Strength: 10, Frequency: 2412},
+	}
+	tests := []struct {
+		name string
+		opts FilterOptions
+		want []string
+	}{
+		{"zero value", FilterOptions{}, []string{"bravo", "", "Alpha", "charlie", "delta"}},
+		{"drop hidden", FilterOptions{DropHidden: true}, []string{"bravo", "Alpha", "charlie", "delta"}},
+		{"min strength", FilterOptions{MinStrength: 40}, []string{"bravo", "", "Alpha", "charlie"}},
+		{"2.4GHz", FilterOptions{Band: Band2_4GHz}, []string{"bravo", "delta"}},
+		{"5GHz", FilterOptions{Band: Band5GHz}, []string{"", "Alpha"}},
+		{"6GHz", FilterOptions{Band: Band6GHz}, []string{"charlie"}},
+		// Equal strengths keep their original order
+		{"by strength", FilterOptions{SortBy: SortStrength}, []string{"", "Alpha", "charlie", "bravo", "delta"}},
+		{"by name", FilterOptions{DropHidden: true, SortBy: SortName}, []string{"Alpha", "bravo", "charlie", "delta"}},
+		{"combined", FilterOptions{DropHidden: true, MinStrength: 50, SortBy: SortName}, []string{"Alpha", "charlie"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ssidNames(FilterAndSortSSIDs(infos, tt.opts)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterAndSortSSIDs(%+v) = %q, want %q", tt.opts, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterAndSortSSIDsKeepsInput(t *testing.T) {
+	infos := []SSIDInfo{
+		{Name: "b", Strength: 10},
+		{Name: "a", Strength: 20},
+		{Name: "", Strength: 30},
+	}
+	want := append([]SSIDInfo(nil), infos...)
+	FilterAndSortSSIDs(infos, FilterOptions{DropHidden: true, SortBy: SortName})
+	FilterAndSortSSIDs(infos, FilterOptions{SortBy: SortStrength})
+	if !reflect.DeepEqual(infos, want) {
+		t.Errorf("FilterAndSortSSIDs modified its input: %+v, want %+v", infos, want)
+	}
+}
@@ -0,0 +1,100 @@
+package network
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Potsdam-Sensors/GoLinuxToolkit/unix"
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	NetworkManagerStatisticsInterface = "org.freedesktop.NetworkManager.Device.Statistics"
+
+	procNetDevPath = "/proc/net/dev"
+)
+
+// DeviceStats holds the bytes received and sent by a device since it came up.
+type DeviceStats struct {
+	RxBytes uint64
+	TxBytes uint64
+}
+
+// SetStatisticsRefreshRate sets how often NetworkManager updates the device's counters, 0 stops updating them.
+func SetStatisticsRefreshRate(conn *dbus.Conn, devObj *dbus.BusObject, rateMs uint32) error {
+	return unix.SetProperty(conn, NetworkManagerInterface, NetworkManagerStatisticsInterface, (*devObj).Path(), "RefreshRateMs", rateMs)
+}
+
+/*
+GetDeviceStatistics returns the device's byte counters. NetworkManager only keeps them current
+while the refresh rate is nonzero (see SetStatisticsRefreshRate), so when it is 0, or the
+device isn't managed, the kernel's counters in /proc/net/dev are read instead.
+*/
+func GetDeviceStatistics(conn *dbus.Conn, devObj *dbus.BusObject) (*DeviceStats, error) {
+	devPath := (*devObj).Path()
+	managed, err := GetDeviceManaged(conn, devObj)
+	if err != nil {
+		return nil, err
+	}
+	rate, err := unix.GetProperty[uint32](conn, NetworkManagerInterface, NetworkManagerStatisticsInterface, devPath, "RefreshRateMs")
+	if err != nil {
+		return nil, err
+	}
+	if !managed || rate == 0 {
+		ifName, err := GetDeviceInterfaceName(conn, devObj)
+		if err != nil {
+			return nil, err
+		}
+		return GetInterfaceStatistics(ifName)
+	}
+
+	stats := &DeviceStats{}
+	stats.RxBytes, err = unix.GetProperty[uint64](conn, NetworkManagerInterface, NetworkManagerStatisticsInterface, devPath, "RxBytes")
+	if err != nil {
+		return nil, err
+	}
+	stats.TxBytes, err = unix.GetProperty[uint64](conn, NetworkManagerInterface, NetworkManagerStatisticsInterface, devPath, "TxBytes")
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// GetInterfaceStatistics reads the interface's byte counters from /proc/net/dev, without going through NetworkManager.
+func GetInterfaceStatistics(ifName string) (*DeviceStats, error) {
+	f, err := os.Open(procNetDevPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %v", procNetDevPath, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// "  eth0: rx_bytes rx_packets ... (8 rx fields) tx_bytes tx_packets ...", after two header lines
+		name, counters, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || strings.TrimSpace(name) != ifName {
+			continue
+		}
+		fields := strings.Fields(counters)
+		if len(fields) < 9 {
+			return nil, fmt.Errorf("unexpected %s line for %s: %q", procNetDevPath, ifName, scanner.Text())
+		}
+		stats := &DeviceStats{}
+		stats.RxBytes, err = strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse rx bytes of %s: %v", ifName, err)
+		}
+		stats.TxBytes, err = strconv.ParseUint(fields[8], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse tx bytes of %s: %v", ifName, err)
+		}
+		return stats, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", procNetDevPath, err)
+	}
+	return nil, fmt.Errorf("%w: no interface named \"%s\" in %s", ErrDeviceNotFound, ifName, procNetDevPath)
+}
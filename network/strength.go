@@ -0,0 +1,165 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+/*
+C <- signal strength of the device's active access point, in percent (0-100)
+
+The current strength is sent first; afterwards a value is sent whenever it
+changes, including when the device roams to a different access point.
+*/
+type StrengthSubscription struct {
+	C    chan uint8
+	Stop func()
+	Join func()
+}
+
+func propertiesChangedMatch(path dbus.ObjectPath, iface string) []dbus.MatchOption {
+	return []dbus.MatchOption{
+		dbus.WithMatchObjectPath(path),
+		dbus.WithMatchInterface(DbusPropertiesInterface),
+		dbus.WithMatchMember(DbusPropertiesChangedMember),
+		dbus.WithMatchArg(0, iface),
+	}
+}
+
+func getActiveAccessPoint(devObj *dbus.BusObject) (dbus.ObjectPath, error) {
+	variant, err := (*devObj).GetProperty(NetworkManagerWirelessInterface + ".ActiveAccessPoint")
+	if err != nil {
+		return "", fmt.Errorf("error reading ActiveAccessPoint: %v", err)
+	}
+	apPath, ok := variant.Value().(dbus.ObjectPath)
+	if !ok {
+		return "", fmt.Errorf("unexpected type for ActiveAccessPoint: %T", variant.Value())
+	}
+	return apPath, nil
+}
+
+func goParseStrengthSignals(ctx context.Context, wg *sync.WaitGroup, conn *dbus.Conn, devPath dbus.ObjectPath, apPath dbus.ObjectPath, sigCh chan *dbus.Signal, outCh chan uint8, o subscriptionOptions) {
+	defer wg.Done()
+	defer conn.RemoveSignal(sigCh)
+	defer conn.RemoveMatchSignal(propertiesChangedMatch(devPath, NetworkManagerWirelessInterface)...)
+	defer func() {
+		if apPath != "/" {
+			conn.RemoveMatchSignal(propertiesChangedMatch(apPath, NetworkManagerAccessPointInterface)...)
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig, ok := <-sigCh:
+			if !ok || sig == nil {
+				return
+			}
+			if sig.Path == devPath {
+				variant, ok := changedProperty(sig, NetworkManagerWirelessInterface, "ActiveAccessPoint")
+				if !ok {
+					continue
+				}
+				newAp, ok := variant.Value().(dbus.ObjectPath)
+				if !ok || newAp == apPath {
+					continue
+				}
+				if apPath != "/" {
+					conn.RemoveMatchSignal(propertiesChangedMatch(apPath, NetworkManagerAccessPointInterface)...)
+				}
+				apPath = newAp
+				if apPath == "/" {
+					continue
+				}
+				err := conn.AddMatchSignal(propertiesChangedMatch(apPath, NetworkManagerAccessPointInterface)...)
+				if err != nil {
+					o.log().Warn("Failed to watch access point", "accessPoint", apPath, "err", err)
+					continue
+				}
+				strength, err := getAccessPointStrength(conn, apPath)
+				if err != nil {
+					o.log().Warn("Failed to read access point strength", "err", err)
+					continue
+				}
+				select {
+				case outCh <- strength:
+				case <-ctx.Done():
+					return
+				}
+			} else if sig.Path == apPath {
+				variant, ok := changedProperty(sig, NetworkManagerAccessPointInterface, "Strength")
+				if !ok {
+					continue
+				}
+				strength, ok := variant.Value().(uint8)
+				if !ok {
+					continue
+				}
+				select {
+				case outCh <- strength:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}
+}
+
+func getAccessPointStrength(conn *dbus.Conn, apPath dbus.ObjectPath) (uint8, error) {
+	variant, err := conn.Object(NetworkManagerInterface, apPath).GetProperty(NetworkManagerAccessPointInterface + ".Strength")
+	if err != nil {
+		return 0, fmt.Errorf("error reading Strength of %s: %v", apPath, err)
+	}
+	strength, ok := variant.Value().(uint8)
+	if !ok {
+		return 0, fmt.Errorf("unexpected type for Strength: %T", variant.Value())
+	}
+	return strength, nil
+}
+
+// SubscribeActiveApStrength reports the signal strength of the access point devObj is connected to, following it across roams.
+func SubscribeActiveApStrength(conn *dbus.Conn, devObj *dbus.BusObject, opts ...SubscriptionOption) (*StrengthSubscription, error) {
+	o := newSubscriptionOptions(opts)
+	devPath := (*devObj).Path()
+	apPath, err := getActiveAccessPoint(devObj)
+	if err != nil {
+		return nil, err
+	}
+
+	err = conn.AddMatchSignal(propertiesChangedMatch(devPath, NetworkManagerWirelessInterface)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add match rule for %s: %w", devPath, err)
+	}
+	outCh := make(chan uint8, 20)
+	if apPath != "/" {
+		err = conn.AddMatchSignal(propertiesChangedMatch(apPath, NetworkManagerAccessPointInterface)...)
+		if err != nil {
+			conn.RemoveMatchSignal(propertiesChangedMatch(devPath, NetworkManagerWirelessInterface)...)
+			return nil, fmt.Errorf("failed to add match rule for %s: %w", apPath, err)
+		}
+		strength, err := getAccessPointStrength(conn, apPath)
+		if err == nil {
+			// outCh is still empty, this can't block
+			outCh <- strength
+		} else {
+			o.log().Warn("Failed to read access point strength", "err", err)
+		}
+	}
+
+	sigCh := make(chan *dbus.Signal, 20)
+	conn.Signal(sigCh)
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go goParseStrengthSignals(ctx, wg, conn, devPath, apPath, sigCh, outCh, o)
+	ret := &StrengthSubscription{
+		C:    outCh,
+		Stop: cancel,
+		Join: wg.Wait,
+	}
+	return ret, nil
+}
@@ -0,0 +1,41 @@
+package network
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+)
+
+/*
+ActivateVPN brings up the saved VPN connection (e.g. OpenVPN or WireGuard) given by its UUID or
+settings path, returning the path of the new active connection. NetworkManager picks the device,
+so the connection it tunnels through has to be up already.
+*/
+func ActivateVPN(conn *dbus.Conn, connUUIDorPath string) (dbus.ObjectPath, error) {
+	connPath := dbus.ObjectPath(connUUIDorPath)
+	if !strings.HasPrefix(connUUIDorPath, "/") {
+		var err error
+		connPath, err = GetConnectionPathByUUID(conn, connUUIDorPath)
+		if err != nil {
+			return "", err
+		}
+	} else if !connPath.IsValid() {
+		return "", fmt.Errorf("invalid connection path %q", connUUIDorPath)
+	}
+
+	settings, err := getSettings(conn, connPath)
+	if err != nil {
+		return "", err
+	}
+	connType, _ := settings["connection"]["type"].Value().(string)
+	if connType != "vpn" && connType != "wireguard" {
+		return "", fmt.Errorf("connection %s is not a VPN (type \"%s\")", connPath, connType)
+	}
+	return ActivateConnection(conn, connPath, dbus.ObjectPath("/"))
+}
+
+// DeactivateVPN takes down a VPN brought up by ActivateVPN, keeping its saved connection.
+func DeactivateVPN(conn *dbus.Conn, activeConnPath dbus.ObjectPath) error {
+	return DeactivateConnection(conn, activeConnPath)
+}
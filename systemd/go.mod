@@ -2,4 +2,4 @@ module github.com/Potsdam-Sensors/GoLinuxToolkit/systemd
 
 go 1.21.5
 
-require github.com/godbus/dbus v4.1.0+incompatible // indirect
+require github.com/godbus/dbus/v5 v5.1.0
@@ -0,0 +1,89 @@
+package systemd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"time"
+)
+
+/*
+Priority is the syslog level, 0 (emerg) to 7 (debug), 6 (info) for entries that don't set one.
+*/
+type JournalEntry struct {
+	Timestamp time.Time
+	Priority  int
+	Message   string
+}
+
+// journalRecord holds the fields used from `journalctl -o json`, values are strings except for
+// MESSAGE which is an array of bytes when it isn't valid UTF-8.
+type journalRecord struct {
+	RealtimeTimestamp string          `json:"__REALTIME_TIMESTAMP"`
+	Priority          string          `json:"PRIORITY"`
+	Message           json.RawMessage `json:"MESSAGE"`
+}
+
+func (r journalRecord) toEntry() (JournalEntry, error) {
+	entry := JournalEntry{Priority: 6}
+	us, err := strconv.ParseInt(r.RealtimeTimestamp, 10, 64)
+	if err != nil {
+		return entry, fmt.Errorf("bad timestamp \"%s\": %v", r.RealtimeTimestamp, err)
+	}
+	entry.Timestamp = time.UnixMicro(us)
+	if r.Priority != "" {
+		entry.Priority, err = strconv.Atoi(r.Priority)
+		if err != nil {
+			return entry, fmt.Errorf("bad priority \"%s\": %v", r.Priority, err)
+		}
+	}
+	if len(r.Message) > 0 && r.Message[0] == '[' {
+		var raw []byte
+		err = json.Unmarshal(r.Message, &raw)
+		entry.Message = string(raw)
+	} else if len(r.Message) > 0 {
+		err = json.Unmarshal(r.Message, &entry.Message)
+	}
+	if err != nil {
+		return entry, fmt.Errorf("bad message: %v", err)
+	}
+	return entry, nil
+}
+
+/*
+GetServiceLogs returns up to the last `lines` journal entries of the unit, oldest first, including
+systemd's own messages about it. It runs `journalctl -o json`, which must be installed, and reading
+other units' logs needs root or membership in the systemd-journal group.
+*/
+func GetServiceLogs(serviceName string, lines int) ([]JournalEntry, error) {
+	if lines <= 0 {
+		return nil, fmt.Errorf("lines must be positive, got %d", lines)
+	}
+	out, err := exec.Command("journalctl", "-u", serviceName, "-n", strconv.Itoa(lines), "-o", "json", "--no-pager").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run journalctl: %v", err)
+	}
+
+	entries := make([]JournalEntry, 0, lines)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var record journalRecord
+		err = json.Unmarshal(scanner.Bytes(), &record)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing journal entry: %v", err)
+		}
+		entry, err := record.toEntry()
+		if err != nil {
+			return nil, fmt.Errorf("error parsing journal entry: %v", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading journalctl output: %v", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,290 @@
+package systemd
+
+import (
+	"context"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// The functions below are kept for backwards compatibility, each runs the Manager method of the same name on the system bus.
+
+func CheckServiceStatus(serviceName string) (bool, error) {
+	m, err := NewSystemManager()
+	if err != nil {
+		return false, err
+	}
+	return m.CheckServiceStatus(serviceName)
+}
+
+func StartService(serviceName string) error {
+	m, err := NewSystemManager()
+	if err != nil {
+		return err
+	}
+	return m.StartService(serviceName)
+}
+
+func StartServiceContext(ctx context.Context, serviceName string) error {
+	m, err := NewSystemManager()
+	if err != nil {
+		return err
+	}
+	return m.StartServiceContext(ctx, serviceName)
+}
+
+func StopService(serviceName string) error {
+	m, err := NewSystemManager()
+	if err != nil {
+		return err
+	}
+	return m.StopService(serviceName)
+}
+
+func StopServiceContext(ctx context.Context, serviceName string) error {
+	m, err := NewSystemManager()
+	if err != nil {
+		return err
+	}
+	return m.StopServiceContext(ctx, serviceName)
+}
+
+func StartServices(serviceNames []string) (map[string]error, error) {
+	m, err := NewSystemManager()
+	if err != nil {
+		return nil, err
+	}
+	return m.StartServices(serviceNames)
+}
+
+func StopServices(serviceNames []string) (map[string]error, error) {
+	m, err := NewSystemManager()
+	if err != nil {
+		return nil, err
+	}
+	return m.StopServices(serviceNames)
+}
+
+func RestartService(serviceName string) error {
+	m, err := NewSystemManager()
+	if err != nil {
+		return err
+	}
+	return m.RestartService(serviceName)
+}
+
+func RestartServiceContext(ctx context.Context, serviceName string) error {
+	m, err := NewSystemManager()
+	if err != nil {
+		return err
+	}
+	return m.RestartServiceContext(ctx, serviceName)
+}
+
+func ReloadService(serviceName string) error {
+	m, err := NewSystemManager()
+	if err != nil {
+		return err
+	}
+	return m.ReloadService(serviceName)
+}
+
+func ReloadServiceContext(ctx context.Context, serviceName string) error {
+	m, err := NewSystemManager()
+	if err != nil {
+		return err
+	}
+	return m.ReloadServiceContext(ctx, serviceName)
+}
+
+func ReloadOrRestartService(serviceName string) error {
+	m, err := NewSystemManager()
+	if err != nil {
+		return err
+	}
+	return m.ReloadOrRestartService(serviceName)
+}
+
+func ReloadOrRestartServiceContext(ctx context.Context, serviceName string) error {
+	m, err := NewSystemManager()
+	if err != nil {
+		return err
+	}
+	return m.ReloadOrRestartServiceContext(ctx, serviceName)
+}
+
+func MaskUnit(serviceName string, runtime bool) ([]UnitFileChange, error) {
+	m, err := NewSystemManager()
+	if err != nil {
+		return nil, err
+	}
+	return m.MaskUnit(serviceName, runtime)
+}
+
+func UnmaskUnit(serviceName string, runtime bool) ([]UnitFileChange, error) {
+	m, err := NewSystemManager()
+	if err != nil {
+		return nil, err
+	}
+	return m.UnmaskUnit(serviceName, runtime)
+}
+
+func GetUnitInfo(serviceName string) (*UnitInfo, error) {
+	m, err := NewSystemManager()
+	if err != nil {
+		return nil, err
+	}
+	return m.GetUnitInfo(serviceName)
+}
+
+func ListUnits(patterns []string) ([]UnitStatus, error) {
+	m, err := NewSystemManager()
+	if err != nil {
+		return nil, err
+	}
+	return m.ListUnits(patterns)
+}
+
+func ListFailedUnits() ([]UnitStatus, error) {
+	m, err := NewSystemManager()
+	if err != nil {
+		return nil, err
+	}
+	return m.ListFailedUnits()
+}
+
+func ResetFailedService(serviceName string) error {
+	m, err := NewSystemManager()
+	if err != nil {
+		return err
+	}
+	return m.ResetFailedService(serviceName)
+}
+
+func KillService(serviceName string, signal int32, who string) error {
+	m, err := NewSystemManager()
+	if err != nil {
+		return err
+	}
+	return m.KillService(serviceName, signal, who)
+}
+
+func GetUnitFileState(serviceName string) (string, error) {
+	m, err := NewSystemManager()
+	if err != nil {
+		return "", err
+	}
+	return m.GetUnitFileState(serviceName)
+}
+
+func IsServiceEnabled(serviceName string) (bool, error) {
+	m, err := NewSystemManager()
+	if err != nil {
+		return false, err
+	}
+	return m.IsServiceEnabled(serviceName)
+}
+
+func DaemonReload() error {
+	m, err := NewSystemManager()
+	if err != nil {
+		return err
+	}
+	return m.DaemonReload()
+}
+
+func DaemonReloadContext(ctx context.Context) error {
+	m, err := NewSystemManager()
+	if err != nil {
+		return err
+	}
+	return m.DaemonReloadContext(ctx)
+}
+
+func StartServiceWithOptions(ctx context.Context, serviceName string, opts JobOptions) error {
+	m, err := NewSystemManager()
+	if err != nil {
+		return err
+	}
+	return m.StartServiceWithOptions(ctx, serviceName, opts)
+}
+
+func StopServiceWithOptions(ctx context.Context, serviceName string, opts JobOptions) error {
+	m, err := NewSystemManager()
+	if err != nil {
+		return err
+	}
+	return m.StopServiceWithOptions(ctx, serviceName, opts)
+}
+
+func IsolateTarget(targetName string) error {
+	m, err := NewSystemManager()
+	if err != nil {
+		return err
+	}
+	return m.IsolateTarget(targetName)
+}
+
+func IsolateTargetContext(ctx context.Context, targetName string) error {
+	m, err := NewSystemManager()
+	if err != nil {
+		return err
+	}
+	return m.IsolateTargetContext(ctx, targetName)
+}
+
+func GetServicePID(serviceName string) (uint32, error) {
+	m, err := NewSystemManager()
+	if err != nil {
+		return 0, err
+	}
+	return m.GetServicePID(serviceName)
+}
+
+func GetServiceMemoryCurrent(serviceName string) (uint64, error) {
+	m, err := NewSystemManager()
+	if err != nil {
+		return 0, err
+	}
+	return m.GetServiceMemoryCurrent(serviceName)
+}
+
+func GetServiceRestartCount(serviceName string) (uint32, error) {
+	m, err := NewSystemManager()
+	if err != nil {
+		return 0, err
+	}
+	return m.GetServiceRestartCount(serviceName)
+}
+
+func GetServiceUptime(serviceName string) (time.Duration, error) {
+	m, err := NewSystemManager()
+	if err != nil {
+		return 0, err
+	}
+	return m.GetServiceUptime(serviceName)
+}
+
+func StartTransientUnit(name string, props []Property) (dbus.ObjectPath, error) {
+	m, err := NewSystemManager()
+	if err != nil {
+		return "", err
+	}
+	return m.StartTransientUnit(name, props)
+}
+
+func GetUnitProperty(serviceName string, iface string, prop string) (dbus.Variant, error) {
+	m, err := NewSystemManager()
+	if err != nil {
+		return dbus.Variant{}, err
+	}
+	return m.GetUnitProperty(serviceName, iface, prop)
+}
+
+func GetAllUnitProperties(serviceName string, iface string) (map[string]dbus.Variant, error) {
+	m, err := NewSystemManager()
+	if err != nil {
+		return nil, err
+	}
+	return m.GetAllUnitProperties(serviceName, iface)
+}
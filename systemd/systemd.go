@@ -1,12 +1,17 @@
 package systemd
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
+	"math"
+	"sort"
+	"strings"
 	"time"
 
-	"github.com/godbus/dbus"
+	"github.com/godbus/dbus/v5"
 )
 
 const (
@@ -14,16 +19,171 @@ const (
 	systemObjectPath         = dbus.ObjectPath("/org/freedesktop/systemd1")
 	systemdGetUnitMethod     = "org.freedesktop.systemd1.Manager.GetUnit"
 	dbusGetPropertyMethod    = "org.freedesktop.DBus.Properties.Get"
+	dbusGetAllPropertyMethod = "org.freedesktop.DBus.Properties.GetAll"
 	systemdUnit              = "org.freedesktop.systemd1.Unit"
+	systemdServiceInterface  = "org.freedesktop.systemd1.Service"
 	systemdUnitStateProperty = "ActiveState"
 	systemdStopUnitMethod    = "org.freedesktop.systemd1.Manager.StopUnit"
 	systemdStartUnitMethod   = "org.freedesktop.systemd1.Manager.StartUnit"
+	systemdRestartUnitMethod = "org.freedesktop.systemd1.Manager.RestartUnit"
+	systemdReloadUnitMethod  = "org.freedesktop.systemd1.Manager.ReloadUnit"
+
+	systemdReloadOrRestartUnitMethod = "org.freedesktop.systemd1.Manager.ReloadOrRestartUnit"
+	systemdMaskUnitFilesMethod       = "org.freedesktop.systemd1.Manager.MaskUnitFiles"
+	systemdUnmaskUnitFilesMethod     = "org.freedesktop.systemd1.Manager.UnmaskUnitFiles"
+	systemdListUnitsByPatternsMethod = "org.freedesktop.systemd1.Manager.ListUnitsByPatterns"
+	systemdResetFailedUnitMethod     = "org.freedesktop.systemd1.Manager.ResetFailedUnit"
+	systemdKillUnitMethod            = "org.freedesktop.systemd1.Manager.KillUnit"
+	systemdGetUnitFileStateMethod    = "org.freedesktop.systemd1.Manager.GetUnitFileState"
+	systemdReloadMethod              = "org.freedesktop.systemd1.Manager.Reload"
+	systemdStartTransientUnitMethod  = "org.freedesktop.systemd1.Manager.StartTransientUnit"
 
 	systemdJobRemovedMatchRule = "type='signal',interface='org.freedesktop.systemd1.Manager',member='JobRemoved'"
 	dbusAddMatchRuleMethod     = "org.freedesktop.DBus.AddMatch"
+	dbusRemoveMatchRuleMethod  = "org.freedesktop.DBus.RemoveMatch"
 	dbusJobRemovedSignalName   = "org.freedesktop.systemd1.Manager.JobRemoved"
+
+	// defaultJobTimeout bounds the wait for a job when no context is given
+	defaultJobTimeout = 30 * time.Second
+)
+
+var (
+	// ErrNotServiceUnit is returned when reading a .service-only property of another kind of unit
+	ErrNotServiceUnit = errors.New("not a service unit")
+	// ErrMemoryUnavailable is returned when systemd doesn't track a unit's memory, e.g. with MemoryAccounting=no
+	ErrMemoryUnavailable = errors.New("memory usage not available")
+	// ErrUnitNotActive is returned when asking for the uptime of a unit that isn't running
+	ErrUnitNotActive = errors.New("unit not active")
 )
 
+// Job results reported by systemd's JobRemoved signal
+const (
+	JobResultDone       = "done"
+	JobResultCanceled   = "canceled"
+	JobResultTimeout    = "timeout"
+	JobResultFailed     = "failed"
+	JobResultDependency = "dependency"
+	JobResultSkipped    = "skipped"
+)
+
+/*
+JobResultError is returned when a unit job finishes with a result other than "done".
+Result is one of the JobResult* values; "canceled" means the job was replaced or cancelled by
+another job and "dependency" that a job it depended on failed, both are usually worth a retry.
+*/
+type JobResultError struct {
+	Unit   string
+	Action string
+	Result string
+}
+
+func (e *JobResultError) Error() string {
+	return fmt.Sprintf("job to %s service %s failed (%s)", e.Action, e.Unit, e.Result)
+}
+
+// jobSuperseded reports whether the job never ran because it was cancelled or a dependency failed.
+func jobSuperseded(result string) bool {
+	return result == JobResultCanceled || result == JobResultDependency
+}
+
+// Job modes, deciding what systemd does when the new job conflicts with queued ones
+const (
+	// JobModeReplace replaces conflicting queued jobs, the default
+	JobModeReplace = "replace"
+	// JobModeFail fails the new job instead of replacing a conflicting one
+	JobModeFail = "fail"
+	// JobModeIsolate starts the unit and stops every unit it doesn't depend on
+	JobModeIsolate = "isolate"
+	// JobModeIgnoreDependencies queues the job without pulling in its dependencies
+	JobModeIgnoreDependencies = "ignore-dependencies"
+	// JobModeIgnoreRequirements is like JobModeIgnoreDependencies but still honors ordering
+	JobModeIgnoreRequirements = "ignore-requirements"
+	// JobModeReplaceIrreversibly is JobModeReplace, and the job can't be replaced by later ones
+	JobModeReplaceIrreversibly = "replace-irreversibly"
+)
+
+/*
+JobOptions adjusts the start and stop jobs queued by StartServiceWithOptions and
+StopServiceWithOptions. Mode is one of the JobMode* values, defaulting to JobModeReplace.
+
+SkipPrecheck queues the job without first checking whether the unit is already in the wanted
+state, leaving that to systemd, whose job for such a unit finishes right away. It also skips
+resetting a unit that hit its start limit. Quiet turns off logging for the call, errors are still
+returned.
+*/
+type JobOptions struct {
+	Mode         string
+	SkipPrecheck bool
+	Quiet        bool
+}
+
+// discardLogger drops everything, for JobOptions.Quiet
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func (o JobOptions) logger(m *Manager) *slog.Logger {
+	if o.Quiet {
+		return discardLogger
+	}
+	return m.log()
+}
+
+func (o JobOptions) mode() (string, error) {
+	switch o.Mode {
+	case "":
+		return JobModeReplace, nil
+	case JobModeReplace, JobModeFail, JobModeIsolate, JobModeIgnoreDependencies, JobModeIgnoreRequirements, JobModeReplaceIrreversibly:
+		return o.Mode, nil
+	}
+	return "", fmt.Errorf("invalid job mode %q", o.Mode)
+}
+
+/*
+Manager talks to a systemd instance, the system one (NewSystemManager) or the calling
+user's `systemd --user` instance (NewUserManager).
+*/
+type Manager struct {
+	conn   *dbus.Conn
+	logger *slog.Logger
+}
+
+// SetLogger sets where the Manager logs unit states and job results, nil restores slog.Default().
+func (m *Manager) SetLogger(logger *slog.Logger) {
+	m.logger = logger
+}
+
+func (m *Manager) log() *slog.Logger {
+	if m.logger == nil {
+		return slog.Default()
+	}
+	return m.logger
+}
+
+/*
+NewManager returns a Manager using an existing connection, so one connection can be shared by
+many calls. The connection stays owned by the caller.
+*/
+func NewManager(conn *dbus.Conn) *Manager {
+	return &Manager{conn: conn}
+}
+
+// NewSystemManager returns a Manager for the system instance of systemd.
+func NewSystemManager() (*Manager, error) {
+	conn, err := dbus.SystemBus()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connected to the system bus: %v", err)
+	}
+	return NewManager(conn), nil
+}
+
+// NewUserManager returns a Manager for the per-user instance of systemd, reached over the session bus.
+func NewUserManager() (*Manager, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connected to the session bus: %v", err)
+	}
+	return NewManager(conn), nil
+}
+
 func getSystemdObject(conn *dbus.Conn) (*dbus.BusObject, error) {
 	systemdObj := conn.Object(systemdService, systemObjectPath)
 	if systemdObj == nil {
@@ -43,7 +203,13 @@ func getSystemdUnitObject(conn *dbus.Conn, serviceName string) (*dbus.BusObject,
 	if call.Err != nil {
 		return nil, fmt.Errorf("failed to get unit path %s: %v", serviceName, call.Err)
 	}
-	call.Store(&unitObjectPath)
+	err = call.Store(&unitObjectPath)
+	if err != nil {
+		return nil, fmt.Errorf("error storing unit path of %s: %v", serviceName, err)
+	}
+	if !unitObjectPath.IsValid() {
+		return nil, fmt.Errorf("invalid unit path for %s: %q", serviceName, unitObjectPath)
+	}
 
 	unitObj := conn.Object(systemdService, unitObjectPath)
 	if unitObj == nil {
@@ -53,16 +219,23 @@ func getSystemdUnitObject(conn *dbus.Conn, serviceName string) (*dbus.BusObject,
 }
 
 func getUnitStatus(unitObj *dbus.BusObject) (string, error) {
-	var state string
+	var variant dbus.Variant
 	call := (*unitObj).Call(dbusGetPropertyMethod, 0, systemdUnit, systemdUnitStateProperty)
 	if call.Err != nil {
 		return "", fmt.Errorf("failed to check unit state: %v", call.Err)
 	}
-	call.Store(&state)
+	err := call.Store(&variant)
+	if err != nil {
+		return "", fmt.Errorf("error storing unit state: %v", err)
+	}
+	state, ok := variant.Value().(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected type for unit state: %T", variant.Value())
+	}
 	return state, nil
 }
 
-func checkServiceStatus(conn *dbus.Conn, serviceName string) (*dbus.BusObject, bool, error) {
+func checkServiceStatus(conn *dbus.Conn, logger *slog.Logger, serviceName string) (*dbus.BusObject, bool, error) {
 	unitObj, err := getSystemdUnitObject(conn, serviceName)
 	if err != nil {
 		return nil, false, err
@@ -72,153 +245,874 @@ func checkServiceStatus(conn *dbus.Conn, serviceName string) (*dbus.BusObject, b
 	if err != nil {
 		return nil, false, err
 	}
-	log.Printf("Service %s has unit state: %s", serviceName, unitState)
+	logger.Info("Checked unit state", "unit", serviceName, "state", unitState)
 	return unitObj, !((unitState == "inactive") || (unitState == "failed")), nil
 }
 
-func CheckServiceStatus(serviceName string) (bool, error) {
-	conn, err := dbus.SystemBus()
-	if err != nil {
-		return false, fmt.Errorf("failed to connected to the system bus: %v", err)
+// startLimitHit reports whether the unit is failed because it hit its start rate limit.
+func startLimitHit(unitObj *dbus.BusObject) bool {
+	state, err := getUnitStatus(unitObj)
+	if err != nil || state != "failed" {
+		return false
+	}
+	var variant dbus.Variant
+	call := (*unitObj).Call(dbusGetPropertyMethod, 0, systemdServiceInterface, "Result")
+	if call.Err != nil {
+		return false
+	}
+	if call.Store(&variant) != nil {
+		return false
+	}
+	result, _ := variant.Value().(string)
+	return result == "start-limit-hit"
+}
+
+func resetFailedUnit(systemdObj *dbus.BusObject, serviceName string) error {
+	call := (*systemdObj).Call(systemdResetFailedUnitMethod, 0, serviceName)
+	if call.Err != nil {
+		return fmt.Errorf("failed to reset failed state of unit: %v", call.Err)
 	}
-	// defer conn.Close()
+	return nil
+}
 
-	_, res, err := checkServiceStatus(conn, serviceName)
+func (m *Manager) CheckServiceStatus(serviceName string) (bool, error) {
+	_, res, err := checkServiceStatus(m.conn, m.log(), serviceName)
 	return res, err
 }
 
-func doStopService(systemdObj *dbus.BusObject, serviceName string) (dbus.ObjectPath, error) {
-	// TODO: I bet this job object is useful for waiting for completion of itself
+func doStopService(systemdObj *dbus.BusObject, serviceName string, mode string) (dbus.ObjectPath, error) {
+	return doUnitJob(systemdObj, systemdStopUnitMethod, serviceName, mode)
+}
+
+func doStartService(systemdObj *dbus.BusObject, serviceName string, mode string) (dbus.ObjectPath, error) {
+	return doUnitJob(systemdObj, systemdStartUnitMethod, serviceName, mode)
+}
+
+func doUnitJob(systemdObj *dbus.BusObject, method string, serviceName string, mode string) (dbus.ObjectPath, error) {
 	var jobObjectPath dbus.ObjectPath
-	call := (*systemdObj).Call(systemdStopUnitMethod, 0, serviceName, "replace")
+	call := (*systemdObj).Call(method, 0, serviceName, mode)
 	if call.Err != nil {
-		return "", fmt.Errorf("failed to stop unit: %v", call.Err)
+		return "", fmt.Errorf("failed to call %s: %v", method, call.Err)
+	}
+	err := call.Store(&jobObjectPath)
+	if err != nil {
+		return "", fmt.Errorf("error storing job path: %v", err)
 	}
-	call.Store(&jobObjectPath)
 	return jobObjectPath, nil
 }
 
-func doStartService(systemdObj *dbus.BusObject, serviceName string) (dbus.ObjectPath, error) {
-	// TODO: I bet this job object is useful for waiting for completion of itself
-	var jobObjectPath dbus.ObjectPath
-	call := (*systemdObj).Call(systemdStartUnitMethod, 0, serviceName, "replace")
+/*
+jobWatcher receives systemd's JobRemoved signals from when it is created until close. It has to
+be created before the job is queued, a job that finishes right away (e.g. a oneshot unit) would
+otherwise be removed before anything listens for it.
+*/
+type jobWatcher struct {
+	conn     *dbus.Conn
+	signalCh chan *dbus.Signal
+}
+
+func watchJobs(conn *dbus.Conn) (*jobWatcher, error) {
+	call := conn.BusObject().Call(dbusAddMatchRuleMethod, 0, systemdJobRemovedMatchRule)
 	if call.Err != nil {
-		return "", fmt.Errorf("failed to start unit: %v", call.Err)
+		return nil, fmt.Errorf("failed to add match rule for job signals: %v", call.Err)
 	}
-	call.Store(&jobObjectPath)
-	return jobObjectPath, nil
+	w := &jobWatcher{conn: conn, signalCh: make(chan *dbus.Signal, 10)}
+	conn.Signal(w.signalCh)
+	return w, nil
 }
 
-func waitJobComplete(conn *dbus.Conn, targetJobPath dbus.ObjectPath) (string, error) {
-	conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, systemdJobRemovedMatchRule)
-	signalCh := make(chan *dbus.Signal, 10)
-	conn.Signal(signalCh)
+func (w *jobWatcher) close() {
+	// The connection may be long lived and shared, don't leave the channel registered on it
+	w.conn.RemoveSignal(w.signalCh)
+	w.conn.BusObject().Call(dbusRemoveMatchRuleMethod, 0, systemdJobRemovedMatchRule)
+}
 
-	timer := time.NewTimer(5 * time.Second)
-	defer timer.Stop()
+func waitJobComplete(ctx context.Context, w *jobWatcher, logger *slog.Logger, targetJobPath dbus.ObjectPath) (string, error) {
+	results, err := waitJobsComplete(ctx, w, logger, []dbus.ObjectPath{targetJobPath})
+	if err != nil {
+		return "", err
+	}
+	return results[targetJobPath], nil
+}
 
-	for {
+/*
+waitJobsComplete waits for the JobRemoved signal of every job in targetJobPaths, returning each
+job's result. All jobs share the watcher, so no job's signal is missed while waiting for
+another. If ctx ends first, the results gathered so far are returned along with the error.
+*/
+func waitJobsComplete(ctx context.Context, w *jobWatcher, logger *slog.Logger, targetJobPaths []dbus.ObjectPath) (map[dbus.ObjectPath]string, error) {
+	pending := make(map[dbus.ObjectPath]bool, len(targetJobPaths))
+	for _, jobPath := range targetJobPaths {
+		pending[jobPath] = true
+	}
+	results := make(map[dbus.ObjectPath]string, len(targetJobPaths))
+	for len(pending) > 0 {
 		select {
-		case <-timer.C:
-			return "", errors.New("operation timed out")
-		case signal := <-signalCh:
-			if signal.Name == "org.freedesktop.systemd1.Manager.JobRemoved" {
-				// Extract data from the signal
-				//jobPath, unitName, jobResult
-				if len(signal.Body) < 4 {
-					log.Printf("[Warning] expected length of job signal body to be at least 4: %v", signal.Body)
-					continue
-				}
-				// jobNum, jobPath, serviceName, jobResult := signal.Body[0], signal.Body[1], signal.Body[2], signal.Body[3]
-				jobPath := signal.Body[1]
-				jobResult := signal.Body[3]
-				if jobPath == targetJobPath {
-					switch jobResult := jobResult.(type) {
-					case string:
-						return jobResult, nil
-					default:
-						return "", fmt.Errorf("unexpected jobResult type, got value: %v", jobResult)
-					}
-				}
+		case <-ctx.Done():
+			pendingPaths := make([]string, 0, len(pending))
+			for jobPath := range pending {
+				pendingPaths = append(pendingPaths, string(jobPath))
+			}
+			sort.Strings(pendingPaths)
+			return results, fmt.Errorf("stopped waiting for job(s) %s: %w", strings.Join(pendingPaths, ", "), ctx.Err())
+		case signal, ok := <-w.signalCh:
+			if !ok || signal == nil {
+				return results, fmt.Errorf("signal channel closed while waiting for %d job(s)", len(pending))
+			}
+			if signal.Name != dbusJobRemovedSignalName {
+				continue
+			}
+			// Body is jobNum, jobPath, unitName, jobResult
+			if len(signal.Body) < 4 {
+				logger.Warn("Expected job signal body to have at least 4 values", "body", signal.Body)
+				continue
+			}
+			jobPath, ok := signal.Body[1].(dbus.ObjectPath)
+			if !ok || !pending[jobPath] {
+				continue
+			}
+			jobResult, ok := signal.Body[3].(string)
+			if !ok {
+				return results, fmt.Errorf("unexpected jobResult type, got value: %v", signal.Body[3])
 			}
+			results[jobPath] = jobResult
+			delete(pending, jobPath)
 		}
 	}
+	return results, nil
 }
 
-func StartService(serviceName string) error {
-	conn, err := dbus.SystemBus()
+/*
+StartService starts the unit if it isn't running, waiting up to 30 seconds for the job to finish.
+A unit that failed by hitting its start limit has its failed state reset first, as systemd would
+otherwise refuse to start it.
+*/
+func (m *Manager) StartService(serviceName string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultJobTimeout)
+	defer cancel()
+	return m.StartServiceContext(ctx, serviceName)
+}
+
+/*
+StartServiceContext is StartService, with ctx bounding the wait for the start job.
+A start job that was cancelled or whose dependency failed gives a *JobResultError.
+*/
+func (m *Manager) StartServiceContext(ctx context.Context, serviceName string) error {
+	return m.StartServiceWithOptions(ctx, serviceName, JobOptions{})
+}
+
+// StartServiceWithOptions is StartServiceContext, queueing the start job as opts says.
+func (m *Manager) StartServiceWithOptions(ctx context.Context, serviceName string, opts JobOptions) error {
+	mode, err := opts.mode()
 	if err != nil {
-		return fmt.Errorf("failed to connected to the system bus: %v", err)
+		return err
 	}
-	systemdObj, err := getSystemdObject(conn)
+	logger := opts.logger(m)
+	systemdObj, err := getSystemdObject(m.conn)
 	if err != nil {
 		return fmt.Errorf("failed to get systemd obj: %v", err)
 	}
-	_, res, err := checkServiceStatus(conn, serviceName)
+	if !opts.SkipPrecheck {
+		unitObj, res, err := checkServiceStatus(m.conn, logger, serviceName)
+		if err != nil {
+			return err
+		}
+		if res {
+			logger.Info("Unit is already running", "unit", serviceName)
+			return nil
+		}
+		if startLimitHit(unitObj) {
+			logger.Info("Unit hit its start limit, resetting its failed state", "unit", serviceName)
+			err = resetFailedUnit(systemdObj, serviceName)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	watcher, err := watchJobs(m.conn)
 	if err != nil {
 		return err
 	}
-	if res {
-		log.Printf("Unit %s is already running.", serviceName)
-		return nil
-	}
-	startJobPath, err := doStartService(systemdObj, serviceName)
+	defer watcher.close()
+	startJobPath, err := doStartService(systemdObj, serviceName, mode)
 	if err != nil {
 		return fmt.Errorf("error requesting start job for service: %v", err)
 	}
 
-	jobResult, err := waitJobComplete(conn, startJobPath)
+	jobResult, err := waitJobComplete(ctx, watcher, logger, startJobPath)
 	if err != nil {
-		log.Printf("[Warning] Waiting for start job failed with error: %v", err)
+		return fmt.Errorf("waiting for start job %s of %s: %w", startJobPath, serviceName, err)
 	}
-	log.Printf("Job to start service %s completed with result: %s", serviceName, jobResult)
-	if jobResult == "done" {
+	logger.Info("Start job completed", "unit", serviceName, "result", jobResult)
+	return m.checkJobResult(logger, serviceName, "start", jobResult, true)
+}
+
+// StopService stops the unit if it is running, waiting up to 30 seconds for the job to finish.
+func (m *Manager) StopService(serviceName string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultJobTimeout)
+	defer cancel()
+	return m.StopServiceContext(ctx, serviceName)
+}
+
+// StopServiceContext is StopService, with ctx bounding the wait for the stop job.
+func (m *Manager) StopServiceContext(ctx context.Context, serviceName string) error {
+	return m.StopServiceWithOptions(ctx, serviceName, JobOptions{})
+}
+
+// StopServiceWithOptions is StopServiceContext, queueing the stop job as opts says.
+func (m *Manager) StopServiceWithOptions(ctx context.Context, serviceName string, opts JobOptions) error {
+	mode, err := opts.mode()
+	if err != nil {
+		return err
+	}
+	logger := opts.logger(m)
+	systemdObj, err := getSystemdObject(m.conn)
+	if err != nil {
+		return fmt.Errorf("failed to get systemd obj: %v", err)
+	}
+	if !opts.SkipPrecheck {
+		_, res, err := checkServiceStatus(m.conn, logger, serviceName)
+		if err != nil {
+			return err
+		}
+		if !res {
+			logger.Info("Unit is already stopped", "unit", serviceName)
+			return nil
+		}
+	}
+	watcher, err := watchJobs(m.conn)
+	if err != nil {
+		return err
+	}
+	defer watcher.close()
+	stopJobPath, err := doStopService(systemdObj, serviceName, mode)
+	if err != nil {
+		return fmt.Errorf("error requesting stop job for service: %v", err)
+	}
+
+	jobResult, err := waitJobComplete(ctx, watcher, logger, stopJobPath)
+	if err != nil {
+		return fmt.Errorf("waiting for stop job %s of %s: %w", stopJobPath, serviceName, err)
+	}
+	logger.Info("Stop job completed", "unit", serviceName, "result", jobResult)
+	return m.checkJobResult(logger, serviceName, "stop", jobResult, false)
+}
+
+/*
+checkJobResult turns the result of a start or stop job into an error. A job that didn't finish
+as "done" is only an error if the unit didn't end up in the wanted state anyway, unless it was
+cancelled or its dependency failed.
+*/
+func (m *Manager) checkJobResult(logger *slog.Logger, serviceName string, action string, jobResult string, wantRunning bool) error {
+	if jobResult == JobResultDone {
 		return nil
 	}
-	_, res, err = checkServiceStatus(conn, serviceName)
+	jobErr := &JobResultError{Unit: serviceName, Action: action, Result: jobResult}
+	if jobSuperseded(jobResult) {
+		return jobErr
+	}
+	_, res, err := checkServiceStatus(m.conn, logger, serviceName)
 	if err != nil {
-		return fmt.Errorf("job to start unit failed and checking state of service gave error: %v", err)
-	} else if !res {
-		return fmt.Errorf("job to start service failed (%s) and unit isn't running", jobResult)
+		return fmt.Errorf("job to %s unit failed and checking state of service gave error: %v", action, err)
+	}
+	if res != wantRunning {
+		if wantRunning {
+			return fmt.Errorf("%w and unit isn't running", jobErr)
+		}
+		return fmt.Errorf("%w and unit is still running", jobErr)
 	}
 	return nil
 }
 
-func StopService(serviceName string) error {
-	conn, err := dbus.SystemBus()
+// StartServices starts the units like StartService, queueing all start jobs before waiting for them together.
+func (m *Manager) StartServices(serviceNames []string) (map[string]error, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultJobTimeout)
+	defer cancel()
+	return m.runServiceJobs(ctx, serviceNames, true)
+}
+
+// StopServices stops the units like StopService, queueing all stop jobs before waiting for them together.
+func (m *Manager) StopServices(serviceNames []string) (map[string]error, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultJobTimeout)
+	defer cancel()
+	return m.runServiceJobs(ctx, serviceNames, false)
+}
+
+/*
+runServiceJobs brings every unit to the running state start asks for over the Manager's one
+connection. The returned map holds each unit's outcome, the error only failures that affect
+them all.
+*/
+func (m *Manager) runServiceJobs(ctx context.Context, serviceNames []string, start bool) (map[string]error, error) {
+	method, action := systemdStopUnitMethod, "stop"
+	if start {
+		method, action = systemdStartUnitMethod, "start"
+	}
+	systemdObj, err := getSystemdObject(m.conn)
 	if err != nil {
-		return fmt.Errorf("failed to connected to the system bus: %v", err)
+		return nil, fmt.Errorf("failed to get systemd obj: %v", err)
 	}
-	systemdObj, err := getSystemdObject(conn)
+
+	watcher, err := watchJobs(m.conn)
+	if err != nil {
+		return nil, err
+	}
+	defer watcher.close()
+
+	results := make(map[string]error, len(serviceNames))
+	jobs := make(map[string]dbus.ObjectPath, len(serviceNames))
+	for _, serviceName := range serviceNames {
+		unitObj, res, err := checkServiceStatus(m.conn, m.log(), serviceName)
+		if err != nil {
+			results[serviceName] = err
+			continue
+		}
+		if res == start {
+			m.log().Info("Unit is already in the requested state", "unit", serviceName, "action", action)
+			results[serviceName] = nil
+			continue
+		}
+		if start && startLimitHit(unitObj) {
+			m.log().Info("Unit hit its start limit, resetting its failed state", "unit", serviceName)
+			err = resetFailedUnit(systemdObj, serviceName)
+			if err != nil {
+				results[serviceName] = err
+				continue
+			}
+		}
+		jobPath, err := doUnitJob(systemdObj, method, serviceName, JobModeReplace)
+		if err != nil {
+			results[serviceName] = fmt.Errorf("error requesting %s job for service: %v", action, err)
+			continue
+		}
+		jobs[serviceName] = jobPath
+	}
+
+	jobPaths := make([]dbus.ObjectPath, 0, len(jobs))
+	for _, jobPath := range jobs {
+		jobPaths = append(jobPaths, jobPath)
+	}
+	jobResults, waitErr := waitJobsComplete(ctx, watcher, m.log(), jobPaths)
+
+	for serviceName, jobPath := range jobs {
+		jobResult, done := jobResults[jobPath]
+		if !done {
+			results[serviceName] = fmt.Errorf("waiting for %s job %s of %s: %w", action, jobPath, serviceName, waitErr)
+			continue
+		}
+		m.log().Info("Job completed", "action", action, "unit", serviceName, "result", jobResult)
+		results[serviceName] = m.checkJobResult(m.log(), serviceName, action, jobResult, start)
+	}
+	return results, nil
+}
+
+// runUnitJob queues a job for the unit with the given Manager method and waits for it, any result but "done" gives a *JobResultError.
+func (m *Manager) runUnitJob(ctx context.Context, method string, mode string, action string, serviceName string) error {
+	systemdObj, err := getSystemdObject(m.conn)
 	if err != nil {
 		return fmt.Errorf("failed to get systemd obj: %v", err)
 	}
-	_, res, err := checkServiceStatus(conn, serviceName)
+	watcher, err := watchJobs(m.conn)
 	if err != nil {
 		return err
 	}
-	if !res {
-		log.Printf("Unit %s is already stopped.", serviceName)
-		return nil
+	defer watcher.close()
+	jobPath, err := doUnitJob(systemdObj, method, serviceName, mode)
+	if err != nil {
+		return fmt.Errorf("error requesting %s job for service: %v", action, err)
 	}
-	stopJobPath, err := doStopService(systemdObj, serviceName)
+
+	jobResult, err := waitJobComplete(ctx, watcher, m.log(), jobPath)
 	if err != nil {
-		return fmt.Errorf("error requesting stop job for service: %v", err)
+		return fmt.Errorf("waiting for %s job %s of %s: %w", action, jobPath, serviceName, err)
+	}
+	m.log().Info("Job completed", "action", action, "unit", serviceName, "result", jobResult)
+	if jobResult != JobResultDone {
+		return &JobResultError{Unit: serviceName, Action: action, Result: jobResult}
+	}
+	return nil
+}
+
+// RestartService restarts the unit (starting it if it wasn't running) and waits for the job to finish.
+func (m *Manager) RestartService(serviceName string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultJobTimeout)
+	defer cancel()
+	return m.RestartServiceContext(ctx, serviceName)
+}
+
+// RestartServiceContext is RestartService, with ctx bounding the wait for the restart job.
+func (m *Manager) RestartServiceContext(ctx context.Context, serviceName string) error {
+	return m.runUnitJob(ctx, systemdRestartUnitMethod, JobModeReplace, "restart", serviceName)
+}
+
+/*
+ReloadService asks the unit to reload its configuration and waits for the job to finish.
+Units without an ExecReload= fail the reload job, use ReloadOrRestartService for those.
+*/
+func (m *Manager) ReloadService(serviceName string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultJobTimeout)
+	defer cancel()
+	return m.ReloadServiceContext(ctx, serviceName)
+}
+
+// ReloadServiceContext is ReloadService, with ctx bounding the wait for the reload job.
+func (m *Manager) ReloadServiceContext(ctx context.Context, serviceName string) error {
+	return m.runUnitJob(ctx, systemdReloadUnitMethod, JobModeReplace, "reload", serviceName)
+}
+
+// ReloadOrRestartService reloads the unit if it supports it and restarts it otherwise, like `systemctl reload-or-restart`.
+func (m *Manager) ReloadOrRestartService(serviceName string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultJobTimeout)
+	defer cancel()
+	return m.ReloadOrRestartServiceContext(ctx, serviceName)
+}
+
+// ReloadOrRestartServiceContext is ReloadOrRestartService, with ctx bounding the wait for the job.
+func (m *Manager) ReloadOrRestartServiceContext(ctx context.Context, serviceName string) error {
+	return m.runUnitJob(ctx, systemdReloadOrRestartUnitMethod, JobModeReplace, "reload-or-restart", serviceName)
+}
+
+/*
+UnitFileChange is one change systemd made to the unit files, Type is "symlink" or "unlink".
+*/
+type UnitFileChange struct {
+	Type        string
+	Filename    string
+	Destination string
+}
+
+func (m *Manager) callUnitFilesMethod(method string, args ...interface{}) ([]UnitFileChange, error) {
+	systemdObj, err := getSystemdObject(m.conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get systemd obj: %v", err)
 	}
+	call := (*systemdObj).Call(method, 0, args...)
+	if call.Err != nil {
+		return nil, fmt.Errorf("failed to call %s: %v", method, call.Err)
+	}
+	var changes []UnitFileChange
+	err = call.Store(&changes)
+	if err != nil {
+		return nil, fmt.Errorf("error storing unit file changes: %v", err)
+	}
+	return changes, nil
+}
 
-	jobResult, err := waitJobComplete(conn, stopJobPath)
+/*
+MaskUnit links the unit file to /dev/null so it can't be started, even as a dependency.
+With runtime the mask only lasts until the next reboot. systemd needs a daemon reload to pick up the change.
+*/
+func (m *Manager) MaskUnit(serviceName string, runtime bool) ([]UnitFileChange, error) {
+	return m.callUnitFilesMethod(systemdMaskUnitFilesMethod, []string{serviceName}, runtime, false)
+}
+
+// UnmaskUnit removes a mask made by MaskUnit, runtime must match the one used to mask.
+func (m *Manager) UnmaskUnit(serviceName string, runtime bool) ([]UnitFileChange, error) {
+	return m.callUnitFilesMethod(systemdUnmaskUnitFilesMethod, []string{serviceName}, runtime)
+}
+
+func getAllProperties(obj *dbus.BusObject, iface string) (map[string]dbus.Variant, error) {
+	var props map[string]dbus.Variant
+	call := (*obj).Call(dbusGetAllPropertyMethod, 0, iface)
+	if call.Err != nil {
+		return nil, fmt.Errorf("failed to get properties of %s: %v", iface, call.Err)
+	}
+	err := call.Store(&props)
 	if err != nil {
-		log.Printf("[Warning] Waiting for stop job failed with error: %v", err)
+		return nil, fmt.Errorf("error storing properties of %s: %v", iface, err)
 	}
-	log.Printf("Job to stop service %s completed with result: %s", serviceName, jobResult)
-	if jobResult == "done" {
-		return nil
+	return props, nil
+}
+
+// variantValue returns the named property as T, or T's zero value if it is missing or of another type.
+func variantValue[T any](props map[string]dbus.Variant, name string) T {
+	v, _ := props[name].Value().(T)
+	return v
+}
+
+/*
+MainPID and NRestarts are only set for .service units, ActiveEnterTimestamp is zero
+if the unit was never active.
+*/
+type UnitInfo struct {
+	Name                 string
+	Description          string
+	LoadState            string
+	ActiveState          string
+	SubState             string
+	UnitFileState        string
+	ActiveEnterTimestamp time.Time
+	MainPID              uint32
+	NRestarts            uint32
+}
+
+// GetUnitInfo reads the state of a loaded unit from its Unit (and for services, Service) properties.
+func (m *Manager) GetUnitInfo(serviceName string) (*UnitInfo, error) {
+	unitObj, err := getSystemdUnitObject(m.conn, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	props, err := getAllProperties(unitObj, systemdUnit)
+	if err != nil {
+		return nil, err
+	}
+	info := &UnitInfo{
+		Name:          serviceName,
+		Description:   variantValue[string](props, "Description"),
+		LoadState:     variantValue[string](props, "LoadState"),
+		ActiveState:   variantValue[string](props, "ActiveState"),
+		SubState:      variantValue[string](props, "SubState"),
+		UnitFileState: variantValue[string](props, "UnitFileState"),
+	}
+	if ts := variantValue[uint64](props, "ActiveEnterTimestamp"); ts != 0 {
+		info.ActiveEnterTimestamp = time.UnixMicro(int64(ts))
+	}
+
+	if strings.HasSuffix(serviceName, ".service") {
+		props, err = getAllProperties(unitObj, systemdServiceInterface)
+		if err != nil {
+			return nil, err
+		}
+		info.MainPID = variantValue[uint32](props, "MainPID")
+		info.NRestarts = variantValue[uint32](props, "NRestarts")
+	}
+	return info, nil
+}
+
+/*
+UnitStatus is one entry of ListUnits, field order matches systemd's a(ssssssouso) so it can be stored directly.
+Following is the unit this one follows in state, if any. JobID is 0 when no job is queued.
+*/
+type UnitStatus struct {
+	Name        string
+	Description string
+	LoadState   string
+	ActiveState string
+	SubState    string
+	Following   string
+	Path        dbus.ObjectPath
+	JobID       uint32
+	JobType     string
+	JobPath     dbus.ObjectPath
+}
+
+func (m *Manager) listUnits(states []string, patterns []string) ([]UnitStatus, error) {
+	systemdObj, err := getSystemdObject(m.conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get systemd obj: %v", err)
+	}
+	if states == nil {
+		states = []string{}
+	}
+	if patterns == nil {
+		patterns = []string{}
+	}
+	call := (*systemdObj).Call(systemdListUnitsByPatternsMethod, 0, states, patterns)
+	if call.Err != nil {
+		return nil, fmt.Errorf("failed to list units: %v", call.Err)
+	}
+	var units []UnitStatus
+	err = call.Store(&units)
+	if err != nil {
+		return nil, fmt.Errorf("error storing units: %v", err)
+	}
+	return units, nil
+}
+
+// ListUnits returns the loaded units matching any of the glob patterns (e.g. "*.service"), or all of them if none are given.
+func (m *Manager) ListUnits(patterns []string) ([]UnitStatus, error) {
+	return m.listUnits(nil, patterns)
+}
+
+// ListFailedUnits returns the units in the failed state, filtered by systemd itself.
+func (m *Manager) ListFailedUnits() ([]UnitStatus, error) {
+	return m.listUnits([]string{"failed"}, nil)
+}
+
+// ResetFailedService clears the failed state of the unit, including its start rate limit counter.
+func (m *Manager) ResetFailedService(serviceName string) error {
+	systemdObj, err := getSystemdObject(m.conn)
+	if err != nil {
+		return fmt.Errorf("failed to get systemd obj: %v", err)
+	}
+	return resetFailedUnit(systemdObj, serviceName)
+}
+
+/*
+KillService sends signal to the unit's processes without stopping the unit. who selects the
+processes: "main" for the main process, "control" for the control process (e.g. ExecReload)
+or "all" for every process of the unit.
+*/
+func (m *Manager) KillService(serviceName string, signal int32, who string) error {
+	switch who {
+	case "main", "control", "all":
+	default:
+		return fmt.Errorf("invalid kill target %q, must be one of main, control or all", who)
+	}
+	systemdObj, err := getSystemdObject(m.conn)
+	if err != nil {
+		return fmt.Errorf("failed to get systemd obj: %v", err)
+	}
+	call := (*systemdObj).Call(systemdKillUnitMethod, 0, serviceName, who, signal)
+	if call.Err != nil {
+		return fmt.Errorf("failed to kill unit %s: %v", serviceName, call.Err)
+	}
+	return nil
+}
+
+// GetUnitFileState returns the unit file's install state, e.g. "enabled", "disabled", "static", "masked" or "linked".
+func (m *Manager) GetUnitFileState(serviceName string) (string, error) {
+	systemdObj, err := getSystemdObject(m.conn)
+	if err != nil {
+		return "", fmt.Errorf("failed to get systemd obj: %v", err)
+	}
+	call := (*systemdObj).Call(systemdGetUnitFileStateMethod, 0, serviceName)
+	if call.Err != nil {
+		return "", fmt.Errorf("failed to get unit file state of %s: %v", serviceName, call.Err)
+	}
+	var state string
+	err = call.Store(&state)
+	if err != nil {
+		return "", fmt.Errorf("error storing unit file state: %v", err)
+	}
+	return state, nil
+}
+
+// IsServiceEnabled reports whether the unit is enabled to start at boot, permanently or for this boot only.
+func (m *Manager) IsServiceEnabled(serviceName string) (bool, error) {
+	state, err := m.GetUnitFileState(serviceName)
+	if err != nil {
+		return false, err
 	}
-	_, res, err = checkServiceStatus(conn, serviceName)
+	return state == "enabled" || state == "enabled-runtime", nil
+}
+
+/*
+DaemonReload reloads the manager's configuration (like `systemctl daemon-reload`) so new or
+changed unit files are picked up, waiting up to 30 seconds for the reload to finish.
+*/
+func (m *Manager) DaemonReload() error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultJobTimeout)
+	defer cancel()
+	return m.DaemonReloadContext(ctx)
+}
+
+// DaemonReloadContext is DaemonReload, with ctx bounding the wait. systemd only replies once the reload is done.
+func (m *Manager) DaemonReloadContext(ctx context.Context) error {
+	systemdObj, err := getSystemdObject(m.conn)
 	if err != nil {
-		return fmt.Errorf("job to stop unit failed and checking state of service gave error: %v", err)
-	} else if res {
-		return fmt.Errorf("job to stop service failed (%s) and unit is still running", jobResult)
+		return fmt.Errorf("failed to get systemd obj: %v", err)
+	}
+	call := (*systemdObj).CallWithContext(ctx, systemdReloadMethod, 0)
+	if call.Err != nil {
+		return fmt.Errorf("failed to reload systemd: %v", call.Err)
 	}
 	return nil
 }
+
+/*
+IsolateTarget starts the target and stops every unit it doesn't pull in, like `systemctl isolate`,
+waiting up to 30 seconds for the job to finish. The target must allow isolation (AllowIsolate=yes).
+*/
+func (m *Manager) IsolateTarget(targetName string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultJobTimeout)
+	defer cancel()
+	return m.IsolateTargetContext(ctx, targetName)
+}
+
+// IsolateTargetContext is IsolateTarget, with ctx bounding the wait for the job.
+func (m *Manager) IsolateTargetContext(ctx context.Context, targetName string) error {
+	if !strings.HasSuffix(targetName, ".target") {
+		return fmt.Errorf("can only isolate a target, got \"%s\"", targetName)
+	}
+	return m.runUnitJob(ctx, systemdStartUnitMethod, JobModeIsolate, "isolate", targetName)
+}
+
+// getServiceProperty reads a property of the Service interface of a loaded .service unit.
+func getServiceProperty[T any](conn *dbus.Conn, serviceName string, prop string) (T, error) {
+	var value T
+	if !strings.HasSuffix(serviceName, ".service") {
+		return value, fmt.Errorf("%w: %s has no %s property", ErrNotServiceUnit, serviceName, prop)
+	}
+	return getUnitProperty[T](conn, serviceName, systemdServiceInterface, prop)
+}
+
+// getUnitProperty reads a property of one of the loaded unit's interfaces.
+func getUnitProperty[T any](conn *dbus.Conn, unitName string, iface string, prop string) (T, error) {
+	var value T
+	variant, err := getUnitPropertyVariant(conn, unitName, iface, prop)
+	if err != nil {
+		return value, err
+	}
+	value, ok := variant.Value().(T)
+	if !ok {
+		return value, fmt.Errorf("unexpected type for %s: %T", prop, variant.Value())
+	}
+	return value, nil
+}
+
+func getUnitPropertyVariant(conn *dbus.Conn, unitName string, iface string, prop string) (dbus.Variant, error) {
+	var variant dbus.Variant
+	unitObj, err := getSystemdUnitObject(conn, unitName)
+	if err != nil {
+		return variant, err
+	}
+	call := (*unitObj).Call(dbusGetPropertyMethod, 0, iface, prop)
+	if call.Err != nil {
+		return variant, fmt.Errorf("failed to get %s of %s: %v", prop, unitName, call.Err)
+	}
+	err = call.Store(&variant)
+	if err != nil {
+		return variant, fmt.Errorf("error storing %s: %v", prop, err)
+	}
+	return variant, nil
+}
+
+// unitInterface expands a short interface name like "Service" to "org.freedesktop.systemd1.Service".
+func unitInterface(iface string) string {
+	if strings.Contains(iface, ".") {
+		return iface
+	}
+	return systemdService + "." + iface
+}
+
+// GetServicePID returns the PID of the service's main process, 0 if it isn't running.
+func (m *Manager) GetServicePID(serviceName string) (uint32, error) {
+	return getServiceProperty[uint32](m.conn, serviceName, "MainPID")
+}
+
+// GetServiceMemoryCurrent returns the memory used by the service's processes in bytes, or ErrMemoryUnavailable if systemd doesn't track it.
+func (m *Manager) GetServiceMemoryCurrent(serviceName string) (uint64, error) {
+	memory, err := getServiceProperty[uint64](m.conn, serviceName, "MemoryCurrent")
+	if err != nil {
+		return 0, err
+	}
+	// systemd reports "unset" as the maximum value
+	if memory == math.MaxUint64 {
+		return 0, fmt.Errorf("%w for %s", ErrMemoryUnavailable, serviceName)
+	}
+	return memory, nil
+}
+
+// GetServiceRestartCount returns how often systemd restarted the service automatically since it was loaded, ErrNotServiceUnit for other units.
+func (m *Manager) GetServiceRestartCount(serviceName string) (uint32, error) {
+	return getServiceProperty[uint32](m.conn, serviceName, "NRestarts")
+}
+
+// GetServiceUptime returns how long the unit has been active, or ErrUnitNotActive if it isn't.
+func (m *Manager) GetServiceUptime(serviceName string) (time.Duration, error) {
+	unitObj, err := getSystemdUnitObject(m.conn, serviceName)
+	if err != nil {
+		return 0, err
+	}
+	state, err := getUnitStatus(unitObj)
+	if err != nil {
+		return 0, err
+	}
+	if state != "active" && state != "reloading" {
+		return 0, fmt.Errorf("%w: %s is %s", ErrUnitNotActive, serviceName, state)
+	}
+	// Microseconds since the epoch, 0 if the unit never entered the active state
+	enteredUs, err := getUnitProperty[uint64](m.conn, serviceName, systemdUnit, "ActiveEnterTimestamp")
+	if err != nil {
+		return 0, err
+	}
+	if enteredUs == 0 {
+		return 0, fmt.Errorf("%w: %s has no active enter timestamp", ErrUnitNotActive, serviceName)
+	}
+	return time.Since(time.UnixMicro(int64(enteredUs))), nil
+}
+
+// Property is one setting of a transient unit, as the unit file directive Name would set it.
+type Property struct {
+	Name  string
+	Value dbus.Variant
+}
+
+// execCommand is one entry of ExecStart, field order matches systemd's (sasb)
+type execCommand struct {
+	Path          string
+	Argv          []string
+	IgnoreFailure bool
+}
+
+// PropExecStart runs path with args when the unit starts, args don't include path itself.
+func PropExecStart(path string, args ...string) Property {
+	argv := append([]string{path}, args...)
+	return Property{Name: "ExecStart", Value: dbus.MakeVariant([]execCommand{{Path: path, Argv: argv}})}
+}
+
+// PropDescription sets the unit's description.
+func PropDescription(description string) Property {
+	return Property{Name: "Description", Value: dbus.MakeVariant(description)}
+}
+
+// PropRemainAfterExit keeps the unit active after its processes exit.
+func PropRemainAfterExit(remain bool) Property {
+	return Property{Name: "RemainAfterExit", Value: dbus.MakeVariant(remain)}
+}
+
+/*
+StartTransientUnit creates the unit from props and starts it, the way systemd-run does, waiting up
+to 30 seconds for the start job. name must end in the unit type, e.g. ".service" or ".scope". The
+unit only lives until it stops and systemd garbage collects it.
+*/
+func (m *Manager) StartTransientUnit(name string, props []Property) (dbus.ObjectPath, error) {
+	systemdObj, err := getSystemdObject(m.conn)
+	if err != nil {
+		return "", fmt.Errorf("failed to get systemd obj: %v", err)
+	}
+	if props == nil {
+		props = []Property{}
+	}
+	// Auxiliary units, a(sa(sv)), aren't supported
+	aux := []struct {
+		Name  string
+		Props []Property
+	}{}
+
+	watcher, err := watchJobs(m.conn)
+	if err != nil {
+		return "", err
+	}
+	defer watcher.close()
+	var jobPath dbus.ObjectPath
+	call := (*systemdObj).Call(systemdStartTransientUnitMethod, 0, name, JobModeFail, props, aux)
+	if call.Err != nil {
+		return "", fmt.Errorf("failed to start transient unit %s: %v", name, call.Err)
+	}
+	err = call.Store(&jobPath)
+	if err != nil {
+		return "", fmt.Errorf("error storing job path: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultJobTimeout)
+	defer cancel()
+	jobResult, err := waitJobComplete(ctx, watcher, m.log(), jobPath)
+	if err != nil {
+		return jobPath, fmt.Errorf("waiting for start job %s of %s: %w", jobPath, name, err)
+	}
+	m.log().Info("Job completed", "action", "start", "unit", name, "result", jobResult)
+	if jobResult != JobResultDone {
+		return jobPath, &JobResultError{Unit: name, Action: "start", Result: jobResult}
+	}
+	return jobPath, nil
+}
+
+/*
+GetUnitProperty reads any property of the unit, for those without a dedicated function (e.g.
+"ControlGroup" of "Unit" or "TasksCurrent" of "Service"). iface is the full interface name or just
+its last part: "Unit", "Service", "Socket", "Timer", ...
+*/
+func (m *Manager) GetUnitProperty(serviceName string, iface string, prop string) (dbus.Variant, error) {
+	return getUnitPropertyVariant(m.conn, serviceName, unitInterface(iface), prop)
+}
+
+// GetAllUnitProperties reads every property of one of the unit's interfaces in a single call, iface as for GetUnitProperty.
+func (m *Manager) GetAllUnitProperties(serviceName string, iface string) (map[string]dbus.Variant, error) {
+	unitObj, err := getSystemdUnitObject(m.conn, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	return getAllProperties(unitObj, unitInterface(iface))
+}
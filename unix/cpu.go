@@ -0,0 +1,107 @@
+package unix
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const procStatPath = "/proc/stat"
+
+/*
+CPUUsage is the share of time the CPUs were busy, in percent (0-100).
+Cores holds one value per online core, in the order /proc/stat lists them (cpu0, cpu1, ...).
+*/
+type CPUUsage struct {
+	Total float64
+	Cores []float64
+}
+
+type cpuTimes struct {
+	busy  uint64
+	total uint64
+}
+
+// readCPUTimes returns the aggregate times followed by those of each core, in jiffies.
+func readCPUTimes() ([]cpuTimes, error) {
+	f, err := os.Open(procStatPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %v", procStatPath, err)
+	}
+	defer f.Close()
+	return parseCPUTimes(f)
+}
+
+// parseCPUTimes parses the cpu lines of /proc/stat content.
+func parseCPUTimes(r io.Reader) ([]cpuTimes, error) {
+	var times []cpuTimes
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 || !strings.HasPrefix(fields[0], "cpu") {
+			continue
+		}
+		// user nice system idle iowait irq softirq steal; guest time is already counted in user
+		var t cpuTimes
+		for i, field := range fields[1:] {
+			if i >= 8 {
+				break
+			}
+			v, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s line %q: %v", procStatPath, scanner.Text(), err)
+			}
+			t.total += v
+			if i != 3 && i != 4 {
+				t.busy += v
+			}
+		}
+		times = append(times, t)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", procStatPath, err)
+	}
+	if len(times) == 0 {
+		return nil, fmt.Errorf("no cpu lines in %s", procStatPath)
+	}
+	return times, nil
+}
+
+func cpuPercent(before, after cpuTimes) float64 {
+	if after.total <= before.total || after.busy < before.busy {
+		return 0
+	}
+	return float64(after.busy-before.busy) / float64(after.total-before.total) * 100
+}
+
+/*
+GetCPUUsage samples /proc/stat twice, interval apart, and returns the CPU usage over that window.
+It blocks for interval, as a single sample only gives totals since boot.
+*/
+func GetCPUUsage(interval time.Duration) (*CPUUsage, error) {
+	before, err := readCPUTimes()
+	if err != nil {
+		return nil, err
+	}
+	time.Sleep(interval)
+	after, err := readCPUTimes()
+	if err != nil {
+		return nil, err
+	}
+	if len(after) != len(before) {
+		return nil, fmt.Errorf("number of cpus changed while sampling (%d -> %d)", len(before)-1, len(after)-1)
+	}
+
+	usage := &CPUUsage{
+		Total: cpuPercent(before[0], after[0]),
+		Cores: make([]float64, 0, len(after)-1),
+	}
+	for i := 1; i < len(after); i++ {
+		usage.Cores = append(usage.Cores, cpuPercent(before[i], after[i]))
+	}
+	return usage, nil
+}
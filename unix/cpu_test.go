@@ -0,0 +1,63 @@
+package unix
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCPUTimes(t *testing.T) {
+	stat := `cpu  100 10 50 800 40 5 5 0 20 0
+cpu0 60 5 25 400 20 3 2 0 20 0
+cpu1 40 5 25 400 20 2 3 0 0 0
+intr 12345 0 0
+ctxt 67890
+btime 1700000000
+`
+	got, err := parseCPUTimes(strings.NewReader(stat))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// busy skips idle and iowait, guest time is left out of both
+	want := []cpuTimes{
+		{busy: 170, total: 1010},
+		{busy: 95, total: 515},
+		{busy: 75, total: 495},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseCPUTimes() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseCPUTimesErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		stat string
+	}{
+		{"no cpu lines", "intr 1 2 3\nctxt 4\n"},
+		{"bad value", "cpu  1 2 x 4 5\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseCPUTimes(strings.NewReader(tt.stat)); err == nil {
+				t.Error("parseCPUTimes() succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestCPUPercent(t *testing.T) {
+	tests := []struct {
+		before, after cpuTimes
+		want          float64
+	}{
+		{cpuTimes{busy: 100, total: 1000}, cpuTimes{busy: 150, total: 1100}, 50},
+		{cpuTimes{busy: 100, total: 1000}, cpuTimes{busy: 100, total: 1000}, 0},
+		{cpuTimes{busy: 100, total: 1000}, cpuTimes{busy: 90, total: 1100}, 0},
+	}
+	for _, tt := range tests {
+		if got := cpuPercent(tt.before, tt.after); got != tt.want {
+			t.Errorf("cpuPercent(%+v, %+v) = %v, want %v", tt.before, tt.after, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package unix
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+const procMountsPath = "/proc/mounts"
+
+/*
+DiskUsage of a filesystem, in bytes.
+Free counts every free block while Available leaves out those reserved for root, so Available
+is what an unprivileged process can still write. UsedPercent matches df: Used / (Used + Available).
+*/
+type DiskUsage struct {
+	Total       uint64
+	Free        uint64
+	Available   uint64
+	Used        uint64
+	UsedPercent float64
+}
+
+// GetDiskUsage returns the usage of the filesystem containing path.
+func GetDiskUsage(path string) (*DiskUsage, error) {
+	var st syscall.Statfs_t
+	err := syscall.Statfs(path, &st)
+	if err != nil {
+		return nil, fmt.Errorf("failed to statfs %s: %v", path, err)
+	}
+	bsize := uint64(st.Bsize)
+	usage := &DiskUsage{
+		Total:     st.Blocks * bsize,
+		Free:      st.Bfree * bsize,
+		Available: st.Bavail * bsize,
+	}
+	usage.Used = usage.Total - usage.Free
+	if usage.Used+usage.Available > 0 {
+		usage.UsedPercent = float64(usage.Used) / float64(usage.Used+usage.Available) * 100
+	}
+	return usage, nil
+}
+
+// Mount is a line of /proc/mounts.
+type Mount struct {
+	Device  string
+	Path    string
+	FSType  string
+	Options []string
+}
+
+// unescapeMountField undoes the octal escaping /proc/mounts uses for spaces, tabs, newlines and backslashes.
+func unescapeMountField(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if v, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// GetMountpoints lists the mounted filesystems from /proc/mounts.
+func GetMountpoints() ([]Mount, error) {
+	f, err := os.Open(procMountsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %v", procMountsPath, err)
+	}
+	defer f.Close()
+	return parseMounts(f)
+}
+
+// parseMounts parses /proc/mounts content.
+func parseMounts(r io.Reader) ([]Mount, error) {
+	var mounts []Mount
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// device mountpoint fstype options dump pass
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 {
+			continue
+		}
+		mounts = append(mounts, Mount{
+			Device:  unescapeMountField(fields[0]),
+			Path:    unescapeMountField(fields[1]),
+			FSType:  fields[2],
+			Options: strings.Split(fields[3], ","),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", procMountsPath, err)
+	}
+	return mounts, nil
+}
@@ -0,0 +1,45 @@
+package unix
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnescapeMountField(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"/mnt/data", "/mnt/data"},
+		{`/mnt/my\040disk`, "/mnt/my disk"},
+		{`/mnt/a\011b\012c`, "/mnt/a\tb\nc"},
+		{`/mnt/back\134slash`, `/mnt/back\slash`},
+		{`/mnt/trailing\04`, `/mnt/trailing\04`},
+		{`/mnt/not\999octal`, `/mnt/not\999octal`},
+	}
+	for _, tt := range tests {
+		if got := unescapeMountField(tt.in); got != tt.want {
+			t.Errorf("unescapeMountField(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseMounts(t *testing.T) {
+	mounts := `/dev/mmcblk0p2 / ext4 rw,noatime 0 0
+proc /proc proc rw,nosuid,nodev,noexec,relatime 0 0
+/dev/sda1 /media/usb\040stick vfat rw,relatime,fmask=0022 0 0
+short line
+`
+	got, err := parseMounts(strings.NewReader(mounts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Mount{
+		{Device: "/dev/mmcblk0p2", Path: "/", FSType: "ext4", Options: []string{"rw", "noatime"}},
+		{Device: "proc", Path: "/proc", FSType: "proc", Options: []string{"rw", "nosuid", "nodev", "noexec", "relatime"}},
+		{Device: "/dev/sda1", Path: "/media/usb stick", FSType: "vfat", Options: []string{"rw", "relatime", "fmask=0022"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseMounts() = %+v, want %+v", got, want)
+	}
+}
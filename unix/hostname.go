@@ -0,0 +1,67 @@
+package unix
+
+import (
+	"fmt"
+	"strings"
+
+	dbus "github.com/godbus/dbus/v5"
+)
+
+const (
+	HostnamedInterface               = "org.freedesktop.hostname1"
+	HostnamedObjectPath              = dbus.ObjectPath("/org/freedesktop/hostname1")
+	HostnamedMethodSetStaticHostname = "org.freedesktop.hostname1.SetStaticHostname"
+
+	// maxHostnameLength is the kernel's limit (HOST_NAME_MAX), stricter than RFC 1123's 253
+	maxHostnameLength = 64
+)
+
+// ValidateHostname checks name is a valid RFC 1123 host name: dot separated labels of letters, digits and hyphens.
+func ValidateHostname(name string) error {
+	if name == "" {
+		return fmt.Errorf("hostname is empty")
+	}
+	if len(name) > maxHostnameLength {
+		return fmt.Errorf("hostname %q is longer than %d characters", name, maxHostnameLength)
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > 63 {
+			return fmt.Errorf("hostname %q has a label of invalid length", name)
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return fmt.Errorf("hostname %q has a label starting or ending with a hyphen", name)
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return fmt.Errorf("hostname %q contains invalid character %q", name, c)
+			}
+		}
+	}
+	return nil
+}
+
+// GetHostname returns the current hostname as reported by hostnamed.
+func GetHostname() (string, error) {
+	conn := GetDBusConn()
+	if conn == nil {
+		return "", fmt.Errorf("failed to connect to SystemBus")
+	}
+	return GetProperty[string](conn, HostnamedInterface, HostnamedInterface, HostnamedObjectPath, "Hostname")
+}
+
+// SetStaticHostname validates name and sets it as the static hostname (/etc/hostname) through hostnamed.
+func SetStaticHostname(name string) error {
+	err := ValidateHostname(name)
+	if err != nil {
+		return err
+	}
+	conn := GetDBusConn()
+	if conn == nil {
+		return fmt.Errorf("failed to connect to SystemBus")
+	}
+	call := conn.Object(HostnamedInterface, HostnamedObjectPath).Call(HostnamedMethodSetStaticHostname, 0, name, false)
+	if call.Err != nil {
+		return fmt.Errorf("failed to set static hostname: %w", call.Err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package unix
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// ifreqFlags is the kernel's struct ifreq with the ifr_flags member of its union, padded to full size
+type ifreqFlags struct {
+	Name  [syscall.IFNAMSIZ]byte
+	Flags uint16
+	_     [22]byte
+}
+
+func ioctlIfreq(fd int, req uintptr, ifr *ifreqFlags) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), req, uintptr(unsafe.Pointer(ifr)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+/*
+SetLinkUp brings the interface up or down with the SIOCSIFFLAGS ioctl, like `ip link set`, without
+going through NetworkManager. This is meant for interfaces NetworkManager doesn't manage, it may
+undo the change on those it does. Needs CAP_NET_ADMIN.
+*/
+func SetLinkUp(ifName string, up bool) error {
+	if len(ifName) == 0 || len(ifName) >= syscall.IFNAMSIZ {
+		return fmt.Errorf("invalid interface name %q", ifName)
+	}
+	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_DGRAM|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open socket: %v", err)
+	}
+	defer syscall.Close(fd)
+
+	var ifr ifreqFlags
+	copy(ifr.Name[:], ifName)
+	err = ioctlIfreq(fd, syscall.SIOCGIFFLAGS, &ifr)
+	if err != nil {
+		return fmt.Errorf("failed to get flags of %s: %v", ifName, err)
+	}
+	state := "down"
+	if up {
+		state = "up"
+		ifr.Flags |= syscall.IFF_UP
+	} else {
+		ifr.Flags &^= syscall.IFF_UP
+	}
+	err = ioctlIfreq(fd, syscall.SIOCSIFFLAGS, &ifr)
+	if err != nil {
+		return fmt.Errorf("failed to set %s %s: %v", ifName, state, err)
+	}
+	return nil
+}
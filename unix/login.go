@@ -0,0 +1,55 @@
+package unix
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dbus "github.com/godbus/dbus/v5"
+)
+
+const (
+	LogindInterface  = "org.freedesktop.login1"
+	LogindObjectPath = dbus.ObjectPath("/org/freedesktop/login1")
+
+	LogindMethodReboot   = "org.freedesktop.login1.Manager.Reboot"
+	LogindMethodPowerOff = "org.freedesktop.login1.Manager.PowerOff"
+	LogindMethodSuspend  = "org.freedesktop.login1.Manager.Suspend"
+
+	// logindCallTimeout bounds a power call, logind answers right away once polkit has decided
+	logindCallTimeout = 10 * time.Second
+)
+
+func callLogindPowerMethod(method string, action string) error {
+	conn := GetDBusConn()
+	if conn == nil {
+		return fmt.Errorf("failed to connect to SystemBus")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), logindCallTimeout)
+	defer cancel()
+	// interactive=false: fail instead of waiting for a polkit authentication prompt
+	call := conn.Object(LogindInterface, LogindObjectPath).CallWithContext(ctx, method, 0, false)
+	if call.Err != nil {
+		return fmt.Errorf("failed to %s: %w", action, call.Err)
+	}
+	return nil
+}
+
+/*
+Reboot asks logind to reboot the machine.
+The caller needs polkit's org.freedesktop.login1.reboot privilege (root has it), otherwise the
+D-Bus access denied error is returned.
+*/
+func Reboot() error {
+	return callLogindPowerMethod(LogindMethodReboot, "reboot")
+}
+
+// PowerOff asks logind to power off the machine, needing the org.freedesktop.login1.power-off polkit privilege.
+func PowerOff() error {
+	return callLogindPowerMethod(LogindMethodPowerOff, "power off")
+}
+
+// Suspend asks logind to suspend the machine, needing the org.freedesktop.login1.suspend polkit privilege.
+func Suspend() error {
+	return callLogindPowerMethod(LogindMethodSuspend, "suspend")
+}
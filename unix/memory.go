@@ -0,0 +1,85 @@
+package unix
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const procMeminfoPath = "/proc/meminfo"
+
+/*
+MemInfo holds the main /proc/meminfo figures, in bytes.
+UsedPercent is the share of MemTotal that isn't MemAvailable, in percent (0-100).
+*/
+type MemInfo struct {
+	MemTotal     uint64
+	MemFree      uint64
+	MemAvailable uint64
+	Buffers      uint64
+	Cached       uint64
+	SwapTotal    uint64
+	SwapFree     uint64
+	UsedPercent  float64
+}
+
+// GetMemoryInfo reads /proc/meminfo.
+func GetMemoryInfo() (*MemInfo, error) {
+	f, err := os.Open(procMeminfoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %v", procMeminfoPath, err)
+	}
+	defer f.Close()
+	return parseMemInfo(f)
+}
+
+// parseMemInfo parses /proc/meminfo content.
+func parseMemInfo(r io.Reader) (*MemInfo, error) {
+	info := &MemInfo{}
+	fields := map[string]*uint64{
+		"MemTotal":     &info.MemTotal,
+		"MemFree":      &info.MemFree,
+		"MemAvailable": &info.MemAvailable,
+		"Buffers":      &info.Buffers,
+		"Cached":       &info.Cached,
+		"SwapTotal":    &info.SwapTotal,
+		"SwapFree":     &info.SwapFree,
+	}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// e.g. "MemTotal:        6158152 kB"
+		name, rest, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		dest, ok := fields[name]
+		if !ok {
+			continue
+		}
+		parts := strings.Fields(rest)
+		if len(parts) == 0 {
+			continue
+		}
+		v, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s value %q: %v", name, parts[0], err)
+		}
+		if len(parts) > 1 && parts[1] == "kB" {
+			v *= 1024
+		}
+		*dest = v
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", procMeminfoPath, err)
+	}
+	if info.MemTotal == 0 {
+		return nil, fmt.Errorf("no MemTotal in %s", procMeminfoPath)
+	}
+	if info.MemAvailable <= info.MemTotal {
+		info.UsedPercent = float64(info.MemTotal-info.MemAvailable) / float64(info.MemTotal) * 100
+	}
+	return info, nil
+}
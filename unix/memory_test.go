@@ -0,0 +1,53 @@
+package unix
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMemInfo(t *testing.T) {
+	meminfo := `MemTotal:        1000000 kB
+MemFree:          200000 kB
+MemAvailable:     250000 kB
+Buffers:           10000 kB
+Cached:           300000 kB
+SwapCached:            0 kB
+SwapTotal:        512000 kB
+SwapFree:         500000 kB
+HugePages_Total:       0
+`
+	got, err := parseMemInfo(strings.NewReader(meminfo))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := MemInfo{
+		MemTotal:     1000000 * 1024,
+		MemFree:      200000 * 1024,
+		MemAvailable: 250000 * 1024,
+		Buffers:      10000 * 1024,
+		Cached:       300000 * 1024,
+		SwapTotal:    512000 * 1024,
+		SwapFree:     500000 * 1024,
+		UsedPercent:  75,
+	}
+	if *got != want {
+		t.Errorf("parseMemInfo() = %+v, want %+v", *got, want)
+	}
+}
+
+func TestParseMemInfoErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		meminfo string
+	}{
+		{"no MemTotal", "MemFree: 100 kB\n"},
+		{"bad value", "MemTotal: lots kB\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseMemInfo(strings.NewReader(tt.meminfo)); err == nil {
+				t.Error("parseMemInfo() succeeded, want an error")
+			}
+		})
+	}
+}
@@ -1,13 +1,44 @@
 package unix
 
 import (
+	"context"
 	"os"
 	"os/signal"
 	"syscall"
 )
 
+var defaultSignals = []os.Signal{syscall.SIGINT, syscall.SIGTERM}
+
+// GetOSSignalChan returns a channel notified of SIGINT and SIGTERM.
 func GetOSSignalChan() chan os.Signal {
-	sigs := make(chan os.Signal, 1)
-	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	return GetOSSignalChanFor(defaultSignals...)
+}
+
+// GetOSSignalChanFor returns a channel notified of the given signals; with none, all incoming signals are relayed.
+func GetOSSignalChanFor(signals ...os.Signal) chan os.Signal {
+	return GetOSSignalChanBuffered(1, signals...)
+}
+
+// GetOSSignalChanBuffered is like GetOSSignalChanFor with a channel buffer of size.
+func GetOSSignalChanBuffered(size int, signals ...os.Signal) chan os.Signal {
+	sigs := make(chan os.Signal, size)
+	signal.Notify(sigs, signals...)
 	return sigs
 }
+
+// NotifyContext returns a context cancelled on SIGINT or SIGTERM. Calling cancel stops listening for the signals.
+func NotifyContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sigs := GetOSSignalChan()
+	go func() {
+		select {
+		case <-sigs:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, func() {
+		cancel()
+		signal.Stop(sigs)
+	}
+}
@@ -0,0 +1,64 @@
+package unix
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const thermalZoneGlob = "/sys/class/thermal/thermal_zone*"
+
+/*
+ThermalZone is a kernel thermal zone.
+Name is the sysfs directory (e.g. "thermal_zone0"), Type what it measures (e.g. "x86_pkg_temp"
+or "cpu-thermal") and Celsius its current temperature.
+*/
+type ThermalZone struct {
+	Name    string
+	Type    string
+	Celsius float64
+}
+
+func readThermalZone(dir string) (ThermalZone, error) {
+	zone := ThermalZone{Name: filepath.Base(dir)}
+	typ, err := os.ReadFile(filepath.Join(dir, "type"))
+	if err != nil {
+		return zone, fmt.Errorf("failed to read type of %s: %v", zone.Name, err)
+	}
+	zone.Type = strings.TrimSpace(string(typ))
+	temp, err := os.ReadFile(filepath.Join(dir, "temp"))
+	if err != nil {
+		return zone, fmt.Errorf("failed to read temp of %s: %v", zone.Name, err)
+	}
+	milli, err := strconv.ParseInt(strings.TrimSpace(string(temp)), 10, 64)
+	if err != nil {
+		return zone, fmt.Errorf("failed to parse temp of %s: %v", zone.Name, err)
+	}
+	zone.Celsius = float64(milli) / 1000
+	return zone, nil
+}
+
+/*
+GetThermalZones reads every thermal zone under /sys/class/thermal. Zones that can't be read are
+left out, the zones that could are still returned along with the joined errors of the others.
+*/
+func GetThermalZones() ([]ThermalZone, error) {
+	dirs, err := filepath.Glob(thermalZoneGlob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list thermal zones: %v", err)
+	}
+	var zones []ThermalZone
+	var errs []error
+	for _, dir := range dirs {
+		zone, err := readThermalZone(dir)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		zones = append(zones, zone)
+	}
+	return zones, errors.Join(errs...)
+}
@@ -0,0 +1,68 @@
+package unix
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	dbus "github.com/godbus/dbus/v5"
+)
+
+const (
+	TimedatedInterface         = "org.freedesktop.timedate1"
+	TimedatedObjectPath        = dbus.ObjectPath("/org/freedesktop/timedate1")
+	TimedatedMethodSetTimezone = "org.freedesktop.timedate1.SetTimezone"
+
+	zoneinfoDir = "/usr/share/zoneinfo"
+)
+
+// GetTimezone returns the system timezone, e.g. "Europe/Berlin".
+func GetTimezone() (string, error) {
+	conn := GetDBusConn()
+	if conn == nil {
+		return "", fmt.Errorf("failed to connect to SystemBus")
+	}
+	return GetProperty[string](conn, TimedatedInterface, TimedatedInterface, TimedatedObjectPath, "Timezone")
+}
+
+// validateTimezone checks tz names a zone file under /usr/share/zoneinfo.
+func validateTimezone(tz string) error {
+	if tz == "" || filepath.IsAbs(tz) || strings.Contains(tz, "..") {
+		return fmt.Errorf("invalid timezone %q", tz)
+	}
+	info, err := os.Stat(filepath.Join(zoneinfoDir, tz))
+	if err != nil {
+		return fmt.Errorf("unknown timezone %q: %v", tz, err)
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("unknown timezone %q: not a zone file", tz)
+	}
+	return nil
+}
+
+// SetTimezone sets the system timezone through timedated, tz must exist under /usr/share/zoneinfo.
+func SetTimezone(tz string) error {
+	err := validateTimezone(tz)
+	if err != nil {
+		return err
+	}
+	conn := GetDBusConn()
+	if conn == nil {
+		return fmt.Errorf("failed to connect to SystemBus")
+	}
+	call := conn.Object(TimedatedInterface, TimedatedObjectPath).Call(TimedatedMethodSetTimezone, 0, tz, false)
+	if call.Err != nil {
+		return fmt.Errorf("failed to set timezone: %w", call.Err)
+	}
+	return nil
+}
+
+// GetTimeSyncStatus reports whether the system clock is synchronized with a remote NTP server.
+func GetTimeSyncStatus() (bool, error) {
+	conn := GetDBusConn()
+	if conn == nil {
+		return false, fmt.Errorf("failed to connect to SystemBus")
+	}
+	return GetProperty[bool](conn, TimedatedInterface, TimedatedInterface, TimedatedObjectPath, "NTPSynchronized")
+}
@@ -7,8 +7,10 @@ import (
 )
 
 const (
-	MethodDbusGetProperty  = "org.freedesktop.DBus.Properties.Get"
-	MethodDbusAddMatchRule = "org.freedesktop.DBus.AddMatch"
+	MethodDbusGetProperty     = "org.freedesktop.DBus.Properties.Get"
+	MethodDbusSetProperty     = "org.freedesktop.DBus.Properties.Set"
+	MethodDbusAddMatchRule    = "org.freedesktop.DBus.AddMatch"
+	MethodDbusRemoveMatchRule = "org.freedesktop.DBus.RemoveMatch"
 
 	SystemdInterface  = "org.freedesktop.systemd1"
 	SystemdObjectPath = dbus.ObjectPath("/org/freedesktop/systemd1")
@@ -40,18 +42,34 @@ const (
 )
 
 /*
-You must defer Conn.Close()
+You must defer Stop()
 */
 type DBusSignalSubscription struct {
-	C    chan *dbus.Signal
-	Conn *dbus.Conn
+	C         chan *dbus.Signal
+	Conn      *dbus.Conn
+	MatchRule string
+
+	ownsConn bool
 }
 
+// MakeDBusSignalSubscription subscribes on a private system bus connection of its own, which Stop closes.
 func (ss *DBusSignalSubscription) MakeDBusSignalSubscription(matchRule string, size int) error {
-	conn, err := dbus.SystemBus()
+	// Not dbus.SystemBus(), closing the shared connection would break every other user of it
+	conn, err := dbus.ConnectSystemBus()
 	if err != nil {
 		return fmt.Errorf("failed to connect to SystemBus: %v", err)
 	}
+	err = ss.MakeDBusSignalSubscriptionOnConn(conn, matchRule, size)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	ss.ownsConn = true
+	return nil
+}
+
+// MakeDBusSignalSubscriptionOnConn is like MakeDBusSignalSubscription but attaches to an existing connection instead of dialing the system bus.
+func (ss *DBusSignalSubscription) MakeDBusSignalSubscriptionOnConn(conn *dbus.Conn, matchRule string, size int) error {
 	call := conn.BusObject().Call(MethodDbusAddMatchRule, 0, matchRule)
 	if call.Err != nil {
 		return call.Err
@@ -61,6 +79,79 @@ func (ss *DBusSignalSubscription) MakeDBusSignalSubscription(matchRule string, s
 	conn.Signal(ch)
 	ss.Conn = conn
 	ss.C = ch
+	ss.MatchRule = matchRule
+	ss.ownsConn = false
+	return nil
+}
+
+// Stop removes the match rule and stops delivery to C. The connection is closed only if the subscription dialed it.
+func (ss *DBusSignalSubscription) Stop() error {
+	if ss.Conn == nil {
+		return nil
+	}
+	call := ss.Conn.BusObject().Call(MethodDbusRemoveMatchRule, 0, ss.MatchRule)
+	ss.Conn.RemoveSignal(ss.C)
+	if ss.ownsConn {
+		ss.Conn.Close()
+	}
+	ss.Conn = nil
+	if call.Err != nil {
+		return fmt.Errorf("failed to remove match rule %q: %v", ss.MatchRule, call.Err)
+	}
+	return nil
+}
+
+// GetProperty reads property prop of interface iface on the object at path owned by dest, storing it as T.
+func GetProperty[T any](conn *dbus.Conn, dest string, iface string, path dbus.ObjectPath, prop string) (T, error) {
+	var value T
+	variant, err := conn.Object(dest, path).GetProperty(iface + "." + prop)
+	if err != nil {
+		return value, fmt.Errorf("failed to read property %s.%s of %s: %v", iface, prop, path, err)
+	}
+	err = variant.Store(&value)
+	if err != nil {
+		return value, fmt.Errorf("error storing property %s.%s: %v", iface, prop, err)
+	}
+	return value, nil
+}
+
+// SetProperty writes value to property prop of interface iface on the object at path owned by dest.
+func SetProperty(conn *dbus.Conn, dest string, iface string, path dbus.ObjectPath, prop string, value interface{}) error {
+	call := conn.Object(dest, path).Call(MethodDbusSetProperty, 0, iface, prop, dbus.MakeVariant(value))
+	if call.Err != nil {
+		return fmt.Errorf("failed to set property %s.%s of %s: %v", iface, prop, path, call.Err)
+	}
+	return nil
+}
+
+// CallMethod calls method on the object at path owned by dest, storing the reply into out unless out is nil.
+func CallMethod(conn *dbus.Conn, dest string, path dbus.ObjectPath, method string, out interface{}, args ...interface{}) error {
+	call := conn.Object(dest, path).Call(method, 0, args...)
+	if call.Err != nil {
+		return fmt.Errorf("error in call to %s: %v", method, call.Err)
+	}
+	if out == nil {
+		return nil
+	}
+	err := call.Store(out)
+	if err != nil {
+		return fmt.Errorf("error storing reply of %s: %v", method, err)
+	}
+	return nil
+}
+
+// ParseSignalBody stores the first len(dest) values of the signal's body into dest, which must be pointers of matching types.
+func ParseSignalBody(sig *dbus.Signal, dest ...interface{}) error {
+	if sig == nil {
+		return fmt.Errorf("nil signal")
+	}
+	if len(sig.Body) < len(dest) {
+		return fmt.Errorf("signal %s from %s has %d values, expected at least %d", sig.Name, sig.Path, len(sig.Body), len(dest))
+	}
+	err := dbus.Store(sig.Body[:len(dest)], dest...)
+	if err != nil {
+		return fmt.Errorf("error storing body of signal %s from %s: %v", sig.Name, sig.Path, err)
+	}
 	return nil
 }
 
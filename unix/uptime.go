@@ -0,0 +1,56 @@
+package unix
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	procUptimePath  = "/proc/uptime"
+	procLoadavgPath = "/proc/loadavg"
+)
+
+// readProcFields returns the whitespace separated fields of a /proc file, requiring at least n of them.
+func readProcFields(path string, n int) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) < n {
+		return nil, fmt.Errorf("expected at least %d fields in %s, got %q", n, path, string(data))
+	}
+	return fields, nil
+}
+
+// GetUptime returns the time since boot from /proc/uptime.
+func GetUptime() (time.Duration, error) {
+	fields, err := readProcFields(procUptimePath, 1)
+	if err != nil {
+		return 0, err
+	}
+	seconds, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse uptime %q: %v", fields[0], err)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// GetLoadAverage returns the 1, 5 and 15 minute load averages from /proc/loadavg.
+func GetLoadAverage() (load1, load5, load15 float64, err error) {
+	fields, err := readProcFields(procLoadavgPath, 3)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	var loads [3]float64
+	for i := range loads {
+		loads[i], err = strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("failed to parse load average %q: %v", fields[i], err)
+		}
+	}
+	return loads[0], loads[1], loads[2], nil
+}
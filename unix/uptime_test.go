@@ -0,0 +1,31 @@
+package unix
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadProcFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "loadavg")
+	if err := os.WriteFile(path, []byte("0.52 0.58 0.59 2/345 12345\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := readProcFields(path, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"0.52", "0.58", "0.59", "2/345", "12345"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readProcFields() = %q, want %q", got, want)
+	}
+
+	if _, err := readProcFields(path, 6); err == nil {
+		t.Error("readProcFields() with too few fields succeeded, want an error")
+	}
+	if _, err := readProcFields(filepath.Join(t.TempDir(), "missing"), 1); err == nil {
+		t.Error("readProcFields() of a missing file succeeded, want an error")
+	}
+}